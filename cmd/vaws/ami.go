@@ -0,0 +1,181 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// amiOutputs holds the responses of the APIs that the ami command combines.
+type amiOutputs struct {
+	images                 *ec2.DescribeImagesOutput
+	instances              []*ec2.DescribeInstancesOutput
+	launchTemplateVersions []*ec2.DescribeLaunchTemplateVersionsOutput
+}
+
+// amiCmd represents the ami command
+var amiCmd = &cobra.Command{
+	Use:   "ami",
+	Short: "Show AMIs owned by the account",
+	Long: `Show AMIs owned by the account and the instances and launch templates that use them.
+Terminated instances are not counted in USED BY.
+LAUNCH TEMPLATES lists the default and latest versions of launch templates that specify the AMI.
+Other versions and launch configurations are not checked, so review them before deregistering AMIs shown by --unused.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		outputs, err := getAmis(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		unusedFlg, err := cmd.Flags().GetBool("unused")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showAmis(outputs, tablewriter.NewWriter(os.Stdout), sortPosition, unusedFlg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(amiCmd)
+	amiCmd.Flags().Bool("unused", false, "Show only AMIs that no instance or the default and latest versions of launch templates use")
+}
+
+func getAmis(cfg aws.Config) (*amiOutputs, error) {
+	var err error
+	outputs := &amiOutputs{}
+	outputs.images, err = getOwnedImages(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.instances, err = getEc2Instances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Auto Scaling groups usually launch the default or latest version of their launch templates
+	outputs.launchTemplateVersions, err = getLaunchTemplateVersions(cfg, &ec2.DescribeLaunchTemplateVersionsInput{
+		Versions: []string{"$Default", "$Latest"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+func getOwnedImages(cfg aws.Config) (*ec2.DescribeImagesOutput, error) {
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeImages API has no pagination
+	output, err := client.DescribeImages(context.TODO(), &ec2.DescribeImagesInput{
+		Owners: []string{"self"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// showAmis shows AMIs with the instances and launch templates that use them.
+// AMIs that neither uses are candidates for deregistration, so unusedFlg narrows the list down to them.
+func showAmis(outputs *amiOutputs, table *tablewriter.Table, sortPosition int, unusedFlg bool) error {
+	header := []string{"NAME", "ID", "CREATION DATE", "DEPRECATION TIME", "ARCHITECTURE", "ROOT DEVICE", "SNAPSHOTS", "INSTANCES", "USED BY", "LAUNCH TEMPLATES"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	// image ID -> instances
+	usedBy := map[string][]string{}
+	for _, o := range outputs.instances {
+		for _, r := range o.Reservations {
+			for _, instance := range r.Instances {
+				if instance.ImageId == nil || instance.State != nil && instance.State.Name == types.InstanceStateNameTerminated {
+					continue
+				}
+				usedBy[*instance.ImageId] = append(usedBy[*instance.ImageId], formatNameId(getNameTag(instance.Tags), *instance.InstanceId))
+			}
+		}
+	}
+	// image ID -> launch template versions, which appear twice when the default version is the latest
+	templatesBy := map[string]map[string]bool{}
+	for _, o := range outputs.launchTemplateVersions {
+		for _, v := range o.LaunchTemplateVersions {
+			if v.LaunchTemplateData == nil || v.LaunchTemplateData.ImageId == nil {
+				continue
+			}
+			imageId := *v.LaunchTemplateData.ImageId
+			if templatesBy[imageId] == nil {
+				templatesBy[imageId] = map[string]bool{}
+			}
+			templatesBy[imageId][fmt.Sprintf("%s:%d", aws.ToString(v.LaunchTemplateName), aws.ToInt64(v.VersionNumber))] = true
+		}
+	}
+	var records [][]string
+	if outputs.images != nil {
+		for _, image := range outputs.images.Images {
+			instances := usedBy[*image.ImageId]
+			var templates []string
+			for t := range templatesBy[*image.ImageId] {
+				templates = append(templates, t)
+			}
+			if unusedFlg && (len(instances) > 0 || len(templates) > 0) {
+				continue
+			}
+			sort.Strings(instances)
+			sort.Strings(templates)
+			var snapshots []string
+			for _, mapping := range image.BlockDeviceMappings {
+				if mapping.Ebs != nil && mapping.Ebs.SnapshotId != nil {
+					snapshots = append(snapshots, *mapping.Ebs.SnapshotId)
+				}
+			}
+			rootDevice := aws.ToString(image.RootDeviceName)
+			if image.RootDeviceType != "" {
+				rootDevice = fmt.Sprintf("%s(%s)", rootDevice, image.RootDeviceType)
+			}
+			records = append(records, []string{
+				aws.ToString(image.Name),
+				*image.ImageId,
+				aws.ToString(image.CreationDate),
+				aws.ToString(image.DeprecationTime),
+				string(image.Architecture),
+				rootDevice,
+				joinValues(snapshots),
+				strconv.Itoa(len(instances)),
+				joinValues(instances),
+				joinValues(templates),
+			})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
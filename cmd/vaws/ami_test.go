@@ -0,0 +1,201 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+var testAmiOutputs = &amiOutputs{
+	images: &ec2.DescribeImagesOutput{
+		Images: []types.Image{
+			{
+				Architecture: "x86_64",
+				BlockDeviceMappings: []types.BlockDeviceMapping{
+					{
+						DeviceName: aws.String("/dev/xvda"),
+						Ebs: &types.EbsBlockDevice{
+							SnapshotId: aws.String("snap-11111111"),
+						},
+					},
+				},
+				CreationDate:   aws.String("2022-01-10T00:00:00.000Z"),
+				ImageId:        aws.String("ami-11111111"),
+				Name:           aws.String("web-20220110"),
+				RootDeviceName: aws.String("/dev/xvda"),
+				RootDeviceType: "ebs",
+			},
+			{
+				Architecture: "x86_64",
+				BlockDeviceMappings: []types.BlockDeviceMapping{
+					{
+						DeviceName: aws.String("/dev/xvda"),
+						Ebs: &types.EbsBlockDevice{
+							SnapshotId: aws.String("snap-22222222"),
+						},
+					},
+					{
+						DeviceName: aws.String("/dev/sdf"),
+						Ebs: &types.EbsBlockDevice{
+							SnapshotId: aws.String("snap-33333333"),
+						},
+					},
+					{
+						DeviceName:  aws.String("/dev/sdb"),
+						VirtualName: aws.String("ephemeral0"),
+					},
+				},
+				CreationDate:    aws.String("2021-06-01T00:00:00.000Z"),
+				DeprecationTime: aws.String("2023-06-01T00:00:00.000Z"),
+				ImageId:         aws.String("ami-22222222"),
+				Name:            aws.String("batch-20210601"),
+				RootDeviceName:  aws.String("/dev/xvda"),
+				RootDeviceType:  "ebs",
+			},
+			{
+				Architecture:   "arm64",
+				CreationDate:   aws.String("2021-01-01T00:00:00.000Z"),
+				ImageId:        aws.String("ami-33333333"),
+				Name:           aws.String("web-20210101"),
+				RootDeviceName: aws.String("/dev/xvda"),
+				RootDeviceType: "ebs",
+			},
+		},
+	},
+	instances: []*ec2.DescribeInstancesOutput{
+		{
+			Reservations: []types.Reservation{
+				{
+					Instances: []types.Instance{
+						{
+							ImageId:    aws.String("ami-11111111"),
+							InstanceId: aws.String("i-11111111"),
+							State: &types.InstanceState{
+								Name: "running",
+							},
+							Tags: []types.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("web1"),
+								},
+							},
+						},
+						{
+							ImageId:    aws.String("ami-11111111"),
+							InstanceId: aws.String("i-22222222"),
+							State: &types.InstanceState{
+								Name: "stopped",
+							},
+						},
+						{
+							ImageId:    aws.String("ami-33333333"),
+							InstanceId: aws.String("i-33333333"),
+							State: &types.InstanceState{
+								Name: "terminated",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	launchTemplateVersions: []*ec2.DescribeLaunchTemplateVersionsOutput{
+		{
+			LaunchTemplateVersions: []types.LaunchTemplateVersion{
+				{
+					DefaultVersion: aws.Bool(true),
+					LaunchTemplateData: &types.ResponseLaunchTemplateData{
+						ImageId: aws.String("ami-11111111"),
+					},
+					LaunchTemplateId:   aws.String("lt-11111111"),
+					LaunchTemplateName: aws.String("web"),
+					VersionNumber:      aws.Int64(1),
+				},
+				{
+					DefaultVersion: aws.Bool(false),
+					LaunchTemplateData: &types.ResponseLaunchTemplateData{
+						ImageId: aws.String("ami-33333333"),
+					},
+					LaunchTemplateId:   aws.String("lt-11111111"),
+					LaunchTemplateName: aws.String("web"),
+					VersionNumber:      aws.Int64(2),
+				},
+				{
+					DefaultVersion: aws.Bool(true),
+					LaunchTemplateData: &types.ResponseLaunchTemplateData{
+						ImageId: aws.String("ami-11111111"),
+					},
+					LaunchTemplateId:   aws.String("lt-22222222"),
+					LaunchTemplateName: aws.String("worker"),
+					VersionNumber:      aws.Int64(4),
+				},
+				{
+					DefaultVersion: aws.Bool(true),
+					LaunchTemplateData: &types.ResponseLaunchTemplateData{
+						ImageId: aws.String("ami-11111111"),
+					},
+					LaunchTemplateId:   aws.String("lt-22222222"),
+					LaunchTemplateName: aws.String("worker"),
+					VersionNumber:      aws.Int64(4),
+				},
+			},
+		},
+	},
+}
+
+func Test_showAmis(t *testing.T) {
+	type args struct {
+		outputs      *amiOutputs
+		sortPosition int
+		unusedFlg    bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs:      testAmiOutputs,
+				sortPosition: 3,
+			},
+			want: `+----------------+--------------+--------------------------+--------------------------+--------------+----------------+-----------------------------+-----------+-----------------------------+------------------+
+|      NAME      |      ID      |      CREATION DATE       |     DEPRECATION TIME     | ARCHITECTURE |  ROOT DEVICE   |          SNAPSHOTS          | INSTANCES |           USED BY           | LAUNCH TEMPLATES |
++----------------+--------------+--------------------------+--------------------------+--------------+----------------+-----------------------------+-----------+-----------------------------+------------------+
+| web-20210101   | ami-33333333 | 2021-01-01T00:00:00.000Z |                          | arm64        | /dev/xvda(ebs) |                             |         0 |                             | web:2            |
+| batch-20210601 | ami-22222222 | 2021-06-01T00:00:00.000Z | 2023-06-01T00:00:00.000Z | x86_64       | /dev/xvda(ebs) | snap-22222222,snap-33333333 |         0 |                             |                  |
+| web-20220110   | ami-11111111 | 2022-01-10T00:00:00.000Z |                          | x86_64       | /dev/xvda(ebs) | snap-11111111               |         2 | i-22222222,web1(i-11111111) | web:1,worker:4   |
++----------------+--------------+--------------------------+--------------------------+--------------+----------------+-----------------------------+-----------+-----------------------------+------------------+
+`,
+		},
+		{
+			name: "unused",
+			args: args{
+				outputs:      testAmiOutputs,
+				sortPosition: 1,
+				unusedFlg:    true,
+			},
+			want: `+----------------+--------------+--------------------------+--------------------------+--------------+----------------+-----------------------------+-----------+---------+------------------+
+|      NAME      |      ID      |      CREATION DATE       |     DEPRECATION TIME     | ARCHITECTURE |  ROOT DEVICE   |          SNAPSHOTS          | INSTANCES | USED BY | LAUNCH TEMPLATES |
++----------------+--------------+--------------------------+--------------------------+--------------+----------------+-----------------------------+-----------+---------+------------------+
+| batch-20210601 | ami-22222222 | 2021-06-01T00:00:00.000Z | 2023-06-01T00:00:00.000Z | x86_64       | /dev/xvda(ebs) | snap-22222222,snap-33333333 |         0 |         |                  |
++----------------+--------------+--------------------------+--------------------------+--------------+----------------+-----------------------------+-----------+---------+------------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showAmis(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition, tt.args.unusedFlg)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,237 @@
+package vaws
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/spf13/cobra"
+)
+
+// ansibleGroupInvalidChars are the characters that Ansible does not allow in group names.
+var ansibleGroupInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// ansibleGroup is a group of the Ansible dynamic inventory JSON.
+type ansibleGroup struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Children []string `json:"children,omitempty"`
+}
+
+// ansibleInventory is the Ansible dynamic inventory of running instances keyed by the same host names as ssh-config.
+type ansibleInventory struct {
+	groups   map[string]*ansibleGroup
+	hostvars map[string]map[string]interface{}
+}
+
+// ansibleCmd represents the inventory ansible command
+var ansibleCmd = &cobra.Command{
+	Use:   "ansible",
+	Short: "Generate an Ansible dynamic inventory",
+	Long: `Generate an Ansible dynamic inventory of running EC2 instances.
+Hosts are grouped by tags, instance type, AZ, VPC and security group name,
+and named in the same way as ssh-config.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		listFlg, err := cmd.Flags().GetBool("list")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		host, err := cmd.Flags().GetString("host")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		publicFlg, err := cmd.Flags().GetBool("public")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if !listFlg && host == "" {
+			fmt.Println("specify --list or --host")
+			os.Exit(1)
+		}
+		columns, err := cmd.Flags().GetStringSlice("columns")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, column := range columns {
+			if _, ok := findEc2Column(column); !ok {
+				fmt.Printf("unknown column %s\n", column)
+				os.Exit(1)
+			}
+		}
+		cfg := newAwsConfig()
+		outputs, err := getEc2Instances(cfg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		refs, err := getEc2References(cfg, outputs, columns)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		inventory := buildAnsibleInventory(outputs, refs, publicFlg)
+		if listFlg {
+			err = showAnsibleList(inventory, os.Stdout)
+		} else {
+			err = showAnsibleHost(inventory, host, os.Stdout)
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	inventoryCmd.AddCommand(ansibleCmd)
+	ansibleCmd.Flags().Bool("list", false, "Output all groups and hosts")
+	ansibleCmd.Flags().String("host", "", "Output the variables of the host")
+	ansibleCmd.Flags().Bool("public", false, "Use public IP addresses as ansible_host")
+	ansibleCmd.Flags().StringSliceP("columns", "c", nil, "Optional ec2 columns that need additional API calls to add to hostvars (subnet,vpc,vcpu,memory,network,gpu,status,events)")
+}
+
+// buildAnsibleInventory returns the running instances that have an address to connect to.
+// The hostvars have the default columns of the ec2 command and every optional column with a value.
+func buildAnsibleInventory(outputs []*ec2.DescribeInstancesOutput, refs *ec2References, public bool) *ansibleInventory {
+	inventory := &ansibleInventory{
+		groups:   map[string]*ansibleGroup{},
+		hostvars: map[string]map[string]interface{}{},
+	}
+	if refs == nil {
+		refs = &ec2References{now: time.Now()}
+	}
+	// Use the same host names as ssh-config so that both can be used together
+	instances := getRunningInstances(outputs)
+	hosts := sshConfigHosts(instances)
+	for _, instance := range instances {
+		host := hosts[*instance.InstanceId]
+		address := aws.ToString(instance.PrivateIpAddress)
+		if public {
+			address = aws.ToString(instance.PublicIpAddress)
+		}
+		if address == "" {
+			continue
+		}
+		var securityGroups []string
+		for _, sg := range instance.SecurityGroups {
+			securityGroups = append(securityGroups, *sg.GroupName)
+		}
+		tags := map[string]string{}
+		for _, tag := range instance.Tags {
+			tags[*tag.Key] = *tag.Value
+		}
+		hostvars := map[string]interface{}{
+			"ansible_host":        address,
+			"ec2_name":            getNameTag(instance.Tags),
+			"ec2_id":              *instance.InstanceId,
+			"ec2_type":            string(instance.InstanceType),
+			"ec2_private_ip":      aws.ToString(instance.PrivateIpAddress),
+			"ec2_public_ip":       aws.ToString(instance.PublicIpAddress),
+			"ec2_state":           string(instance.State.Name),
+			"ec2_security_groups": securityGroups,
+			"ec2_tags":            tags,
+		}
+		if asg := getAsgName(instance.Tags); asg != "" {
+			hostvars["ec2_asg"] = asg
+		}
+		for _, column := range ec2Columns {
+			if value := column.value(instance, refs); value != "" {
+				hostvars["ec2_"+strings.ReplaceAll(column.name, "-", "_")] = value
+			}
+		}
+		inventory.hostvars[host] = hostvars
+		groups := []string{"type_" + string(instance.InstanceType)}
+		if instance.Placement != nil && instance.Placement.AvailabilityZone != nil {
+			groups = append(groups, "az_"+*instance.Placement.AvailabilityZone)
+		}
+		if instance.VpcId != nil {
+			// vpc-12345678 becomes vpc_12345678
+			groups = append(groups, *instance.VpcId)
+		}
+		for _, sg := range securityGroups {
+			groups = append(groups, "sg_"+sg)
+		}
+		for _, tag := range instance.Tags {
+			groups = append(groups, "tag_"+*tag.Key+"_"+*tag.Value)
+		}
+		for _, group := range groups {
+			inventory.addHost(ansibleGroupName(group), host)
+		}
+	}
+	return inventory
+}
+
+func (inventory *ansibleInventory) addHost(group string, host string) {
+	if inventory.groups[group] == nil {
+		inventory.groups[group] = &ansibleGroup{}
+	}
+	for _, h := range inventory.groups[group].Hosts {
+		if h == host {
+			return
+		}
+	}
+	inventory.groups[group].Hosts = append(inventory.groups[group].Hosts, host)
+	sort.Strings(inventory.groups[group].Hosts)
+}
+
+// ansibleGroupName replaces the characters that are invalid in Ansible group names with underscores.
+func ansibleGroupName(name string) string {
+	return ansibleGroupInvalidChars.ReplaceAllString(name, "_")
+}
+
+// showAnsibleList writes the --list output, in which every group is a child of the all group.
+func showAnsibleList(inventory *ansibleInventory, w io.Writer) error {
+	list := map[string]interface{}{
+		"_meta": map[string]interface{}{
+			"hostvars": inventory.hostvars,
+		},
+	}
+	all := &ansibleGroup{}
+	for name, group := range inventory.groups {
+		list[name] = group
+		all.Children = append(all.Children, name)
+	}
+	sort.Strings(all.Children)
+	list["all"] = all
+	return writeJson(list, w)
+}
+
+// showAnsibleHost writes the --host output. An unknown host has no variables.
+func showAnsibleHost(inventory *ansibleInventory, host string, w io.Writer) error {
+	hostvars, ok := inventory.hostvars[host]
+	if !ok {
+		hostvars = map[string]interface{}{}
+	}
+	return writeJson(hostvars, w)
+}
+
+func writeJson(v interface{}, w io.Writer) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
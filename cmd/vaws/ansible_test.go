@@ -0,0 +1,330 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"testing"
+	"time"
+)
+
+var testAnsibleInstances = []*ec2.DescribeInstancesOutput{
+	{
+		Reservations: []types.Reservation{
+			{
+				Instances: []types.Instance{
+					{
+						ImageId:      aws.String("ami-11111111"),
+						InstanceId:   aws.String("i-11111111"),
+						InstanceType: "t3.micro",
+						LaunchTime:   aws.Time(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)),
+						Placement: &types.Placement{
+							AvailabilityZone: aws.String("ap-northeast-1a"),
+						},
+						PrivateIpAddress: aws.String("10.0.1.10"),
+						PublicIpAddress:  aws.String("203.0.113.10"),
+						SecurityGroups: []types.GroupIdentifier{
+							{
+								GroupId:   aws.String("sg-11111111"),
+								GroupName: aws.String("web"),
+							},
+						},
+						State: &types.InstanceState{
+							Name: "running",
+						},
+						SubnetId: aws.String("subnet-aaaaaaaa"),
+						Tags: []types.Tag{
+							{
+								Key:   aws.String("Name"),
+								Value: aws.String("web01"),
+							},
+							{
+								Key:   aws.String("Env"),
+								Value: aws.String("prod"),
+							},
+						},
+						VpcId: aws.String("vpc-12345678"),
+					},
+					{
+						InstanceId:       aws.String("i-22222222"),
+						InstanceType:     "t3.micro",
+						LaunchTime:       aws.Time(time.Date(2022, 2, 2, 0, 0, 0, 0, time.UTC)),
+						PrivateIpAddress: aws.String("10.0.1.11"),
+						SecurityGroups: []types.GroupIdentifier{
+							{
+								GroupId:   aws.String("sg-11111111"),
+								GroupName: aws.String("web"),
+							},
+						},
+						State: &types.InstanceState{
+							Name: "running",
+						},
+						Tags: []types.Tag{
+							{
+								Key:   aws.String("Name"),
+								Value: aws.String("web01"),
+							},
+						},
+					},
+					{
+						InstanceId:       aws.String("i-33333333"),
+						InstanceType:     "m5.large",
+						PrivateIpAddress: aws.String("10.0.1.30"),
+						State: &types.InstanceState{
+							Name: "stopped",
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func Test_showAnsibleList(t *testing.T) {
+	tests := []struct {
+		name   string
+		public bool
+		want   string
+	}{
+		{
+			name:   "private",
+			public: false,
+			want: `{
+  "_meta": {
+    "hostvars": {
+      "web01": {
+        "ansible_host": "10.0.1.10",
+        "ec2_age": "2d",
+        "ec2_ami": "ami-11111111",
+        "ec2_az": "ap-northeast-1a",
+        "ec2_id": "i-11111111",
+        "ec2_launch_time": "2022-02-01T00:00:00Z",
+        "ec2_lifecycle": "on-demand",
+        "ec2_name": "web01",
+        "ec2_private_ip": "10.0.1.10",
+        "ec2_public_ip": "203.0.113.10",
+        "ec2_security_groups": [
+          "web"
+        ],
+        "ec2_state": "running",
+        "ec2_subnet": "subnet-aaaaaaaa",
+        "ec2_tags": {
+          "Env": "prod",
+          "Name": "web01"
+        },
+        "ec2_type": "t3.micro",
+        "ec2_vpc": "vpc-12345678"
+      },
+      "web01-2": {
+        "ansible_host": "10.0.1.11",
+        "ec2_age": "1d",
+        "ec2_id": "i-22222222",
+        "ec2_launch_time": "2022-02-02T00:00:00Z",
+        "ec2_lifecycle": "on-demand",
+        "ec2_name": "web01",
+        "ec2_private_ip": "10.0.1.11",
+        "ec2_public_ip": "",
+        "ec2_security_groups": [
+          "web"
+        ],
+        "ec2_state": "running",
+        "ec2_tags": {
+          "Name": "web01"
+        },
+        "ec2_type": "t3.micro"
+      }
+    }
+  },
+  "all": {
+    "children": [
+      "az_ap_northeast_1a",
+      "sg_web",
+      "tag_Env_prod",
+      "tag_Name_web01",
+      "type_t3_micro",
+      "vpc_12345678"
+    ]
+  },
+  "az_ap_northeast_1a": {
+    "hosts": [
+      "web01"
+    ]
+  },
+  "sg_web": {
+    "hosts": [
+      "web01",
+      "web01-2"
+    ]
+  },
+  "tag_Env_prod": {
+    "hosts": [
+      "web01"
+    ]
+  },
+  "tag_Name_web01": {
+    "hosts": [
+      "web01",
+      "web01-2"
+    ]
+  },
+  "type_t3_micro": {
+    "hosts": [
+      "web01",
+      "web01-2"
+    ]
+  },
+  "vpc_12345678": {
+    "hosts": [
+      "web01"
+    ]
+  }
+}
+`,
+		},
+		{
+			name:   "public",
+			public: true,
+			want: `{
+  "_meta": {
+    "hostvars": {
+      "web01": {
+        "ansible_host": "203.0.113.10",
+        "ec2_age": "2d",
+        "ec2_ami": "ami-11111111",
+        "ec2_az": "ap-northeast-1a",
+        "ec2_id": "i-11111111",
+        "ec2_launch_time": "2022-02-01T00:00:00Z",
+        "ec2_lifecycle": "on-demand",
+        "ec2_name": "web01",
+        "ec2_private_ip": "10.0.1.10",
+        "ec2_public_ip": "203.0.113.10",
+        "ec2_security_groups": [
+          "web"
+        ],
+        "ec2_state": "running",
+        "ec2_subnet": "subnet-aaaaaaaa",
+        "ec2_tags": {
+          "Env": "prod",
+          "Name": "web01"
+        },
+        "ec2_type": "t3.micro",
+        "ec2_vpc": "vpc-12345678"
+      }
+    }
+  },
+  "all": {
+    "children": [
+      "az_ap_northeast_1a",
+      "sg_web",
+      "tag_Env_prod",
+      "tag_Name_web01",
+      "type_t3_micro",
+      "vpc_12345678"
+    ]
+  },
+  "az_ap_northeast_1a": {
+    "hosts": [
+      "web01"
+    ]
+  },
+  "sg_web": {
+    "hosts": [
+      "web01"
+    ]
+  },
+  "tag_Env_prod": {
+    "hosts": [
+      "web01"
+    ]
+  },
+  "tag_Name_web01": {
+    "hosts": [
+      "web01"
+    ]
+  },
+  "type_t3_micro": {
+    "hosts": [
+      "web01"
+    ]
+  },
+  "vpc_12345678": {
+    "hosts": [
+      "web01"
+    ]
+  }
+}
+`,
+		},
+	}
+	refs := &ec2References{now: time.Date(2022, 2, 3, 0, 0, 0, 0, time.UTC)}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := showAnsibleList(buildAnsibleInventory(testAnsibleInstances, refs, tt.public), buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := buf.String()
+			if got != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", got)
+			}
+		})
+	}
+}
+
+func Test_showAnsibleHost(t *testing.T) {
+	tests := []struct {
+		name string
+		host string
+		want string
+	}{
+		{
+			name: "suffixed host",
+			host: "web01-2",
+			want: `{
+  "ansible_host": "10.0.1.11",
+  "ec2_age": "1d",
+  "ec2_id": "i-22222222",
+  "ec2_launch_time": "2022-02-02T00:00:00Z",
+  "ec2_lifecycle": "on-demand",
+  "ec2_name": "web01",
+  "ec2_private_ip": "10.0.1.11",
+  "ec2_public_ip": "",
+  "ec2_security_groups": [
+    "web"
+  ],
+  "ec2_state": "running",
+  "ec2_tags": {
+    "Name": "web01"
+  },
+  "ec2_type": "t3.micro"
+}
+`,
+		},
+		{
+			name: "unknown host",
+			host: "db01",
+			want: `{}
+`,
+		},
+	}
+	refs := &ec2References{now: time.Date(2022, 2, 3, 0, 0, 0, 0, time.UTC)}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := showAnsibleHost(buildAnsibleInventory(testAnsibleInstances, refs, false), tt.host, buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := buf.String()
+			if got != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", got)
+			}
+		})
+	}
+}
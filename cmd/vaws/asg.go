@@ -0,0 +1,181 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	asgMaxRecords = 100
+	// asgTagKey is the tag that Auto Scaling adds to the instances it launches.
+	asgTagKey = "aws:autoscaling:groupName"
+)
+
+// asgOutputs holds the responses of the APIs that the asg command combines.
+type asgOutputs struct {
+	groups  []*autoscaling.DescribeAutoScalingGroupsOutput
+	subnets []*ec2.DescribeSubnetsOutput
+}
+
+// asgCmd represents the asg command
+var asgCmd = &cobra.Command{
+	Use:   "asg",
+	Short: "Show Auto Scaling groups",
+	Long:  `Show Auto Scaling groups`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		outputs, err := getAsgs(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showAsgs(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(asgCmd)
+}
+
+func getAsgs(cfg aws.Config) (*asgOutputs, error) {
+	var err error
+	outputs := &asgOutputs{}
+	outputs.groups, err = getAutoScalingGroups(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.subnets, err = getSubnets(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+func getAutoScalingGroups(cfg aws.Config) ([]*autoscaling.DescribeAutoScalingGroupsOutput, error) {
+	var outputs []*autoscaling.DescribeAutoScalingGroupsOutput
+	var err error
+	client := autoscaling.NewFromConfig(cfg)
+	// The DescribeAutoScalingGroups API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeAutoScalingGroups(context.TODO(), &autoscaling.DescribeAutoScalingGroupsInput{
+		MaxRecords: aws.Int32(asgMaxRecords),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeAutoScalingGroups(context.TODO(), &autoscaling.DescribeAutoScalingGroupsInput{
+			MaxRecords: aws.Int32(asgMaxRecords),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+func showAsgs(outputs *asgOutputs, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"NAME", "MIN", "DESIRED", "MAX", "IN SERVICE", "LAUNCH TEMPLATE", "SUBNET", "TARGET GROUP", "HEALTH CHECK"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	subnetNames := getSubnetNames(outputs.subnets)
+	var records [][]string
+	for _, o := range outputs.groups {
+		for _, group := range o.AutoScalingGroups {
+			inService := 0
+			for _, instance := range group.Instances {
+				if instance.LifecycleState == types.LifecycleStateInService {
+					inService++
+				}
+			}
+			var subnets []string
+			if group.VPCZoneIdentifier != nil && *group.VPCZoneIdentifier != "" {
+				for _, subnetId := range strings.Split(*group.VPCZoneIdentifier, ",") {
+					subnets = append(subnets, formatNameId(subnetNames[subnetId], subnetId))
+				}
+			}
+			var targetGroups []string
+			for _, arn := range group.TargetGroupARNs {
+				targetGroups = append(targetGroups, getTargetGroupName(arn))
+			}
+			records = append(records, []string{
+				*group.AutoScalingGroupName,
+				strconv.Itoa(int(aws.ToInt32(group.MinSize))),
+				strconv.Itoa(int(aws.ToInt32(group.DesiredCapacity))),
+				strconv.Itoa(int(aws.ToInt32(group.MaxSize))),
+				fmt.Sprintf("%d/%d", inService, len(group.Instances)),
+				formatAsgLaunchTemplate(group),
+				joinValues(subnets),
+				joinValues(targetGroups),
+				aws.ToString(group.HealthCheckType),
+			})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// formatAsgLaunchTemplate returns "name:version" of the launch template, or the launch configuration name for older groups.
+func formatAsgLaunchTemplate(group types.AutoScalingGroup) string {
+	spec := group.LaunchTemplate
+	if spec == nil && group.MixedInstancesPolicy != nil && group.MixedInstancesPolicy.LaunchTemplate != nil {
+		spec = group.MixedInstancesPolicy.LaunchTemplate.LaunchTemplateSpecification
+	}
+	if spec != nil {
+		name := aws.ToString(spec.LaunchTemplateName)
+		if name == "" {
+			name = aws.ToString(spec.LaunchTemplateId)
+		}
+		return name + ":" + aws.ToString(spec.Version)
+	}
+	if group.LaunchConfigurationName != nil {
+		return fmt.Sprintf("%s(launch-configuration)", *group.LaunchConfigurationName)
+	}
+	return ""
+}
+
+// getTargetGroupName extracts the name from arn:aws:elasticloadbalancing:region:account:targetgroup/name/id.
+func getTargetGroupName(arn string) string {
+	parts := strings.Split(arn, "/")
+	if len(parts) < 3 {
+		return arn
+	}
+	return parts[len(parts)-2]
+}
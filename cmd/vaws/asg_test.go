@@ -0,0 +1,124 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+func Test_showAsgs(t *testing.T) {
+	type args struct {
+		outputs      *asgOutputs
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs: &asgOutputs{
+					groups: []*autoscaling.DescribeAutoScalingGroupsOutput{
+						{
+							AutoScalingGroups: []types.AutoScalingGroup{
+								{
+									AutoScalingGroupName: aws.String("web-asg"),
+									DesiredCapacity:      aws.Int32(2),
+									HealthCheckType:      aws.String("ELB"),
+									Instances: []types.Instance{
+										{
+											InstanceId:     aws.String("i-11111111"),
+											LifecycleState: "InService",
+										},
+										{
+											InstanceId:     aws.String("i-22222222"),
+											LifecycleState: "InService",
+										},
+										{
+											InstanceId:     aws.String("i-33333333"),
+											LifecycleState: "Terminating",
+										},
+									},
+									LaunchTemplate: &types.LaunchTemplateSpecification{
+										LaunchTemplateName: aws.String("web"),
+										Version:            aws.String("$Latest"),
+									},
+									MaxSize:           aws.Int32(4),
+									MinSize:           aws.Int32(1),
+									TargetGroupARNs:   []string{"arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:targetgroup/web-tg/1111111111111111"},
+									VPCZoneIdentifier: aws.String("subnet-aaaaaaaa,subnet-cccccccc"),
+								},
+								{
+									AutoScalingGroupName: aws.String("batch-asg"),
+									DesiredCapacity:      aws.Int32(0),
+									HealthCheckType:      aws.String("EC2"),
+									MaxSize:              aws.Int32(10),
+									MinSize:              aws.Int32(0),
+									MixedInstancesPolicy: &types.MixedInstancesPolicy{
+										LaunchTemplate: &types.LaunchTemplate{
+											LaunchTemplateSpecification: &types.LaunchTemplateSpecification{
+												LaunchTemplateName: aws.String("batch"),
+												Version:            aws.String("3"),
+											},
+										},
+									},
+									VPCZoneIdentifier: aws.String("subnet-bbbbbbbb"),
+								},
+								{
+									AutoScalingGroupName:    aws.String("legacy-asg"),
+									DesiredCapacity:         aws.Int32(1),
+									HealthCheckType:         aws.String("EC2"),
+									LaunchConfigurationName: aws.String("legacy-lc"),
+									MaxSize:                 aws.Int32(1),
+									MinSize:                 aws.Int32(1),
+								},
+							},
+						},
+					},
+					subnets: []*ec2.DescribeSubnetsOutput{
+						{
+							Subnets: []ec2types.Subnet{
+								{
+									SubnetId: aws.String("subnet-aaaaaaaa"),
+									Tags: []ec2types.Tag{
+										{
+											Key:   aws.String("Name"),
+											Value: aws.String("private-a"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				sortPosition: 1,
+			},
+			want: `+------------+-----+---------+-----+------------+---------------------------------+--------------------------------------------+--------------+--------------+
+|    NAME    | MIN | DESIRED | MAX | IN SERVICE |         LAUNCH TEMPLATE         |                   SUBNET                   | TARGET GROUP | HEALTH CHECK |
++------------+-----+---------+-----+------------+---------------------------------+--------------------------------------------+--------------+--------------+
+| batch-asg  |   0 |       0 |  10 | 0/0        | batch:3                         | subnet-bbbbbbbb                            |              | EC2          |
+| legacy-asg |   1 |       1 |   1 | 0/0        | legacy-lc(launch-configuration) |                                            |              | EC2          |
+| web-asg    |   1 |       2 |   4 | 2/3        | web:$Latest                     | private-a(subnet-aaaaaaaa),subnet-cccccccc | web-tg       | ELB          |
++------------+-----+---------+-----+------------+---------------------------------+--------------------------------------------+--------------+--------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showAsgs(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
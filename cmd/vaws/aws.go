@@ -2,11 +2,17 @@ package vaws
 
 import (
 	"context"
+	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"log"
+	"strings"
 )
 
+// valueSeparator separates multiple values in a table cell. It is set by the --separator flag.
+var valueSeparator = ","
+
 func newAwsConfig() aws.Config {
 	cfg, err := config.LoadDefaultConfig(context.TODO())
 	if err != nil {
@@ -14,3 +20,26 @@ func newAwsConfig() aws.Config {
 	}
 	return cfg
 }
+
+// getNameTag returns the value of the Name tag, or "" if the resource has none.
+func getNameTag(tags []types.Tag) string {
+	for _, tag := range tags {
+		if *tag.Key == "Name" {
+			return *tag.Value
+		}
+	}
+	return ""
+}
+
+// formatNameId renders a resource as "name(id)" in the same way as the SECURITY GROUP column of ec2.
+func formatNameId(name string, id string) string {
+	if name == "" {
+		return id
+	}
+	return fmt.Sprintf("%s(%s)", name, id)
+}
+
+// joinValues joins multiple values of a table cell with valueSeparator.
+func joinValues(values []string) string {
+	return strings.Join(values, valueSeparator)
+}
@@ -0,0 +1,337 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	volumeMaxResults   = 500
+	snapshotMaxResults = 1000
+	// copiedSnapshotVolumeId is the source volume ID of snapshots created by CopySnapshot.
+	copiedSnapshotVolumeId = "vol-ffffffff"
+)
+
+// ebsOutputs holds the responses of the APIs that the ebs command combines.
+type ebsOutputs struct {
+	volumes   []*ec2.DescribeVolumesOutput
+	instances []*ec2.DescribeInstancesOutput
+}
+
+// ebsFilter narrows the volumes down to the ones that may be wasting money.
+type ebsFilter struct {
+	unattached  bool
+	gp2         bool
+	unencrypted bool
+}
+
+// ebsCmd represents the ebs command
+var ebsCmd = &cobra.Command{
+	Use:   "ebs",
+	Short: "Show EBS volumes",
+	Long: `Show EBS volumes.
+The NOTE column points out unattached, gp2 and unencrypted volumes, and the flags narrow the list down to them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		var filter ebsFilter
+		filter.unattached, err = cmd.Flags().GetBool("unattached")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		filter.gp2, err = cmd.Flags().GetBool("gp2")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		filter.unencrypted, err = cmd.Flags().GetBool("unencrypted")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		outputs, err := getEbs(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showEbsVolumes(outputs, tablewriter.NewWriter(os.Stdout), sortPosition, filter)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+// ebsSnapshotsCmd represents the ebs snapshots command
+var ebsSnapshotsCmd = &cobra.Command{
+	Use:   "snapshots",
+	Short: "Show EBS snapshots owned by the account",
+	Long: `Show EBS snapshots owned by the account.
+The NOTE column points out snapshots whose source volume no longer exists.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		orphanedFlg, err := cmd.Flags().GetBool("orphaned")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		cfg := newAwsConfig()
+		snapshots, err := getEbsSnapshots(cfg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		volumes, err := getEbsVolumes(cfg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showEbsSnapshots(snapshots, volumes, time.Now(), tablewriter.NewWriter(os.Stdout), sortPosition, orphanedFlg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ebsCmd)
+	ebsCmd.Flags().Bool("unattached", false, "Show only volumes that are not attached to any instance")
+	ebsCmd.Flags().Bool("gp2", false, "Show only gp2 volumes that can be migrated to gp3")
+	ebsCmd.Flags().Bool("unencrypted", false, "Show only unencrypted volumes")
+	ebsCmd.AddCommand(ebsSnapshotsCmd)
+	ebsSnapshotsCmd.Flags().Bool("orphaned", false, "Show only snapshots whose source volume no longer exists")
+}
+
+func getEbs(cfg aws.Config) (*ebsOutputs, error) {
+	var err error
+	outputs := &ebsOutputs{}
+	outputs.volumes, err = getEbsVolumes(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.instances, err = getEc2Instances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// getEbsVolumes returns the volumes that match all the filters.
+func getEbsVolumes(cfg aws.Config, filters ...types.Filter) ([]*ec2.DescribeVolumesOutput, error) {
+	var outputs []*ec2.DescribeVolumesOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeVolumes API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeVolumes(context.TODO(), &ec2.DescribeVolumesInput{
+		Filters:    filters,
+		MaxResults: aws.Int32(volumeMaxResults),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeVolumes(context.TODO(), &ec2.DescribeVolumesInput{
+			Filters:    filters,
+			MaxResults: aws.Int32(volumeMaxResults),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// getEbsSnapshots returns the snapshots owned by the account. Public and shared snapshots are not included.
+func getEbsSnapshots(cfg aws.Config) ([]*ec2.DescribeSnapshotsOutput, error) {
+	var outputs []*ec2.DescribeSnapshotsOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeSnapshots API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeSnapshots(context.TODO(), &ec2.DescribeSnapshotsInput{
+		MaxResults: aws.Int32(snapshotMaxResults),
+		OwnerIds:   []string{"self"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeSnapshots(context.TODO(), &ec2.DescribeSnapshotsInput{
+			MaxResults: aws.Int32(snapshotMaxResults),
+			NextToken:  output.NextToken,
+			OwnerIds:   []string{"self"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// showEbsVolumes shows volumes and the instances they are attached to.
+// When more than one flag of the filter is set, the volumes that match any of them are shown.
+func showEbsVolumes(outputs *ebsOutputs, table *tablewriter.Table, sortPosition int, filter ebsFilter) error {
+	header := []string{"NAME", "ID", "SIZE", "TYPE", "IOPS", "THROUGHPUT", "ENCRYPTED", "STATE", "INSTANCE", "DEVICE", "NOTE"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	instanceNames := getEc2InstanceNames(outputs.instances)
+	filtered := filter.unattached || filter.gp2 || filter.unencrypted
+	var records [][]string
+	for _, o := range outputs.volumes {
+		for _, volume := range o.Volumes {
+			unattached := len(volume.Attachments) == 0
+			gp2 := volume.VolumeType == types.VolumeTypeGp2
+			unencrypted := !aws.ToBool(volume.Encrypted)
+			if filtered && !(filter.unattached && unattached || filter.gp2 && gp2 || filter.unencrypted && unencrypted) {
+				continue
+			}
+			var notes []string
+			if unattached {
+				notes = append(notes, "unattached")
+			}
+			if gp2 {
+				notes = append(notes, "gp3-eligible")
+			}
+			if unencrypted {
+				notes = append(notes, "unencrypted")
+			}
+			var instances, devices []string
+			for _, attachment := range volume.Attachments {
+				instanceId := aws.ToString(attachment.InstanceId)
+				instances = append(instances, formatNameId(instanceNames[instanceId], instanceId))
+				devices = append(devices, aws.ToString(attachment.Device))
+			}
+			iops := ""
+			if volume.Iops != nil {
+				iops = strconv.Itoa(int(*volume.Iops))
+			}
+			throughput := ""
+			if volume.Throughput != nil {
+				throughput = fmt.Sprintf("%d MiB/s", *volume.Throughput)
+			}
+			records = append(records, []string{
+				getNameTag(volume.Tags),
+				*volume.VolumeId,
+				fmt.Sprintf("%d GiB", aws.ToInt32(volume.Size)),
+				string(volume.VolumeType),
+				iops,
+				throughput,
+				strconv.FormatBool(aws.ToBool(volume.Encrypted)),
+				string(volume.State),
+				joinValues(instances),
+				joinValues(devices),
+				joinValues(notes),
+			})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// showEbsSnapshots shows snapshots and their source volumes.
+// Snapshots copied from other snapshots have no source volume, so they are never reported as orphaned.
+func showEbsSnapshots(snapshots []*ec2.DescribeSnapshotsOutput, volumes []*ec2.DescribeVolumesOutput, now time.Time, table *tablewriter.Table, sortPosition int, orphanedFlg bool) error {
+	header := []string{"NAME", "ID", "VOLUME", "SIZE", "AGE", "START TIME", "STATE", "DESCRIPTION", "NOTE"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	volumeNames := map[string]string{}
+	for _, o := range volumes {
+		for _, volume := range o.Volumes {
+			volumeNames[*volume.VolumeId] = getNameTag(volume.Tags)
+		}
+	}
+	var records [][]string
+	for _, o := range snapshots {
+		for _, snapshot := range o.Snapshots {
+			volumeId := aws.ToString(snapshot.VolumeId)
+			volume := formatNameId(volumeNames[volumeId], volumeId)
+			_, exists := volumeNames[volumeId]
+			orphaned := !exists && volumeId != copiedSnapshotVolumeId
+			if orphanedFlg && !orphaned {
+				continue
+			}
+			note := ""
+			if volumeId == copiedSnapshotVolumeId {
+				volume = ""
+				note = "copied"
+			} else if orphaned {
+				note = "source volume deleted"
+			}
+			age := ""
+			startTime := ""
+			if snapshot.StartTime != nil {
+				age = formatAge(now.Sub(*snapshot.StartTime))
+				startTime = snapshot.StartTime.UTC().Format(time.RFC3339)
+			}
+			records = append(records, []string{
+				getNameTag(snapshot.Tags),
+				*snapshot.SnapshotId,
+				volume,
+				fmt.Sprintf("%d GiB", aws.ToInt32(snapshot.VolumeSize)),
+				age,
+				startTime,
+				string(snapshot.State),
+				aws.ToString(snapshot.Description),
+				note,
+			})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
@@ -0,0 +1,246 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+	"time"
+)
+
+var testEbsVolumes = []*ec2.DescribeVolumesOutput{
+	{
+		Volumes: []types.Volume{
+			{
+				Attachments: []types.VolumeAttachment{
+					{
+						Device:     aws.String("/dev/xvda"),
+						InstanceId: aws.String("i-11111111"),
+					},
+				},
+				Encrypted:  aws.Bool(true),
+				Iops:       aws.Int32(3000),
+				Size:       aws.Int32(8),
+				State:      "in-use",
+				Throughput: aws.Int32(125),
+				VolumeId:   aws.String("vol-11111111"),
+				VolumeType: "gp3",
+			},
+			{
+				Encrypted: aws.Bool(false),
+				Iops:      aws.Int32(300),
+				Size:      aws.Int32(100),
+				State:     "available",
+				Tags: []types.Tag{
+					{
+						Key:   aws.String("Name"),
+						Value: aws.String("old-data"),
+					},
+				},
+				VolumeId:   aws.String("vol-22222222"),
+				VolumeType: "gp2",
+			},
+			{
+				Attachments: []types.VolumeAttachment{
+					{
+						Device:     aws.String("/dev/sdf"),
+						InstanceId: aws.String("i-11111111"),
+					},
+				},
+				Encrypted:  aws.Bool(true),
+				Iops:       aws.Int32(150),
+				Size:       aws.Int32(50),
+				State:      "in-use",
+				VolumeId:   aws.String("vol-33333333"),
+				VolumeType: "gp2",
+			},
+		},
+	},
+}
+
+var testEbsOutputs = &ebsOutputs{
+	volumes: testEbsVolumes,
+	instances: []*ec2.DescribeInstancesOutput{
+		{
+			Reservations: []types.Reservation{
+				{
+					Instances: []types.Instance{
+						{
+							InstanceId: aws.String("i-11111111"),
+							Tags: []types.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("web1"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func Test_showEbsVolumes(t *testing.T) {
+	type args struct {
+		outputs      *ebsOutputs
+		sortPosition int
+		filter       ebsFilter
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs:      testEbsOutputs,
+				sortPosition: 2,
+			},
+			want: `+----------+--------------+---------+------+------+------------+-----------+-----------+------------------+-----------+-------------------------------------+
+|   NAME   |      ID      |  SIZE   | TYPE | IOPS | THROUGHPUT | ENCRYPTED |   STATE   |     INSTANCE     |  DEVICE   |                NOTE                 |
++----------+--------------+---------+------+------+------------+-----------+-----------+------------------+-----------+-------------------------------------+
+|          | vol-11111111 | 8 GiB   | gp3  | 3000 | 125 MiB/s  | true      | in-use    | web1(i-11111111) | /dev/xvda |                                     |
+| old-data | vol-22222222 | 100 GiB | gp2  |  300 |            | false     | available |                  |           | unattached,gp3-eligible,unencrypted |
+|          | vol-33333333 | 50 GiB  | gp2  |  150 |            | true      | in-use    | web1(i-11111111) | /dev/sdf  | gp3-eligible                        |
++----------+--------------+---------+------+------+------------+-----------+-----------+------------------+-----------+-------------------------------------+
+`,
+		},
+		{
+			name: "unattached or unencrypted",
+			args: args{
+				outputs:      testEbsOutputs,
+				sortPosition: 2,
+				filter: ebsFilter{
+					unattached:  true,
+					unencrypted: true,
+				},
+			},
+			want: `+----------+--------------+---------+------+------+------------+-----------+-----------+----------+--------+-------------------------------------+
+|   NAME   |      ID      |  SIZE   | TYPE | IOPS | THROUGHPUT | ENCRYPTED |   STATE   | INSTANCE | DEVICE |                NOTE                 |
++----------+--------------+---------+------+------+------------+-----------+-----------+----------+--------+-------------------------------------+
+| old-data | vol-22222222 | 100 GiB | gp2  |  300 |            | false     | available |          |        | unattached,gp3-eligible,unencrypted |
++----------+--------------+---------+------+------+------------+-----------+-----------+----------+--------+-------------------------------------+
+`,
+		},
+		{
+			name: "gp2",
+			args: args{
+				outputs:      testEbsOutputs,
+				sortPosition: 2,
+				filter: ebsFilter{
+					gp2: true,
+				},
+			},
+			want: `+----------+--------------+---------+------+------+------------+-----------+-----------+------------------+----------+-------------------------------------+
+|   NAME   |      ID      |  SIZE   | TYPE | IOPS | THROUGHPUT | ENCRYPTED |   STATE   |     INSTANCE     |  DEVICE  |                NOTE                 |
++----------+--------------+---------+------+------+------------+-----------+-----------+------------------+----------+-------------------------------------+
+| old-data | vol-22222222 | 100 GiB | gp2  |  300 |            | false     | available |                  |          | unattached,gp3-eligible,unencrypted |
+|          | vol-33333333 | 50 GiB  | gp2  |  150 |            | true      | in-use    | web1(i-11111111) | /dev/sdf | gp3-eligible                        |
++----------+--------------+---------+------+------+------------+-----------+-----------+------------------+----------+-------------------------------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showEbsVolumes(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition, tt.args.filter)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
+
+func Test_showEbsSnapshots(t *testing.T) {
+	snapshots := []*ec2.DescribeSnapshotsOutput{
+		{
+			Snapshots: []types.Snapshot{
+				{
+					Description: aws.String("daily backup"),
+					SnapshotId:  aws.String("snap-11111111"),
+					StartTime:   aws.Time(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)),
+					State:       "completed",
+					VolumeId:    aws.String("vol-11111111"),
+					VolumeSize:  aws.Int32(8),
+				},
+				{
+					SnapshotId: aws.String("snap-22222222"),
+					StartTime:  aws.Time(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)),
+					State:      "completed",
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("before-migration"),
+						},
+					},
+					VolumeId:   aws.String("vol-99999999"),
+					VolumeSize: aws.Int32(200),
+				},
+				{
+					Description: aws.String("Copied for DestinationAmi ami-11111111"),
+					SnapshotId:  aws.String("snap-33333333"),
+					StartTime:   aws.Time(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+					State:       "completed",
+					VolumeId:    aws.String("vol-ffffffff"),
+					VolumeSize:  aws.Int32(8),
+				},
+			},
+		},
+	}
+	type args struct {
+		sortPosition int
+		orphanedFlg  bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				sortPosition: 2,
+			},
+			want: `+------------------+---------------+--------------+---------+------+----------------------+-----------+--------------------------------+-----------------------+
+|       NAME       |      ID       |    VOLUME    |  SIZE   | AGE  |      START TIME      |   STATE   |          DESCRIPTION           |         NOTE          |
++------------------+---------------+--------------+---------+------+----------------------+-----------+--------------------------------+-----------------------+
+|                  | snap-11111111 | vol-11111111 | 8 GiB   | 2d   | 2022-02-01T00:00:00Z | completed | daily backup                   |                       |
+| before-migration | snap-22222222 | vol-99999999 | 200 GiB | 247d | 2021-06-01T00:00:00Z | completed |                                | source volume deleted |
+|                  | snap-33333333 |              | 8 GiB   | 33d  | 2022-01-01T00:00:00Z | completed | Copied for DestinationAmi      | copied                |
+|                  |               |              |         |      |                      |           | ami-11111111                   |                       |
++------------------+---------------+--------------+---------+------+----------------------+-----------+--------------------------------+-----------------------+
+`,
+		},
+		{
+			name: "orphaned",
+			args: args{
+				sortPosition: 2,
+				orphanedFlg:  true,
+			},
+			want: `+------------------+---------------+--------------+---------+------+----------------------+-----------+-------------+-----------------------+
+|       NAME       |      ID       |    VOLUME    |  SIZE   | AGE  |      START TIME      |   STATE   | DESCRIPTION |         NOTE          |
++------------------+---------------+--------------+---------+------+----------------------+-----------+-------------+-----------------------+
+| before-migration | snap-22222222 | vol-99999999 | 200 GiB | 247d | 2021-06-01T00:00:00Z | completed |             | source volume deleted |
++------------------+---------------+--------------+---------+------+----------------------+-----------+-------------+-----------------------+
+`,
+		},
+	}
+	now := time.Date(2022, 2, 3, 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showEbsSnapshots(snapshots, testEbsVolumes, now, tablewriter.NewWriter(&buf), tt.args.sortPosition, tt.args.orphanedFlg)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
@@ -5,15 +5,198 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
 const ec2MaxResults = 1000
 
+// ec2Column is an optional column of the ec2 command selected by the --columns flag.
+type ec2Column struct {
+	name   string
+	header string
+	value  func(instance types.Instance, refs *ec2References) string
+}
+
+// ec2References holds what the optional columns look up besides the instance itself.
+type ec2References struct {
+	subnetNames      map[string]string
+	vpcNames         map[string]string
+	instanceTypes    map[string]instanceTypeSpec
+	instanceStatuses map[string]types.InstanceStatus
+	now              time.Time
+}
+
+var ec2Columns = []ec2Column{
+	{
+		name:   "az",
+		header: "AZ",
+		value: func(instance types.Instance, refs *ec2References) string {
+			if instance.Placement == nil {
+				return ""
+			}
+			return aws.ToString(instance.Placement.AvailabilityZone)
+		},
+	},
+	{
+		name:   "launch-time",
+		header: "LAUNCH_TIME",
+		value: func(instance types.Instance, refs *ec2References) string {
+			if instance.LaunchTime == nil {
+				return ""
+			}
+			return instance.LaunchTime.UTC().Format(time.RFC3339)
+		},
+	},
+	{
+		name:   "age",
+		header: "AGE",
+		value: func(instance types.Instance, refs *ec2References) string {
+			if instance.LaunchTime == nil {
+				return ""
+			}
+			return formatAge(refs.now.Sub(*instance.LaunchTime))
+		},
+	},
+	{
+		name:   "platform",
+		header: "PLATFORM",
+		value: func(instance types.Instance, refs *ec2References) string {
+			return aws.ToString(instance.PlatformDetails)
+		},
+	},
+	{
+		name:   "arch",
+		header: "ARCHITECTURE",
+		value: func(instance types.Instance, refs *ec2References) string {
+			return string(instance.Architecture)
+		},
+	},
+	{
+		name:   "key",
+		header: "KEY",
+		value: func(instance types.Instance, refs *ec2References) string {
+			return aws.ToString(instance.KeyName)
+		},
+	},
+	{
+		name:   "ami",
+		header: "AMI",
+		value: func(instance types.Instance, refs *ec2References) string {
+			return aws.ToString(instance.ImageId)
+		},
+	},
+	{
+		name:   "lifecycle",
+		header: "LIFECYCLE",
+		value: func(instance types.Instance, refs *ec2References) string {
+			if instance.InstanceLifecycle == "" {
+				return "on-demand"
+			}
+			return string(instance.InstanceLifecycle)
+		},
+	},
+	{
+		name:   "subnet",
+		header: "SUBNET",
+		value: func(instance types.Instance, refs *ec2References) string {
+			if instance.SubnetId == nil {
+				return ""
+			}
+			return formatNameId(refs.subnetNames[*instance.SubnetId], *instance.SubnetId)
+		},
+	},
+	{
+		name:   "vpc",
+		header: "VPC",
+		value: func(instance types.Instance, refs *ec2References) string {
+			if instance.VpcId == nil {
+				return ""
+			}
+			return formatNameId(refs.vpcNames[*instance.VpcId], *instance.VpcId)
+		},
+	},
+	{
+		name:   "imds",
+		header: "IMDS_TOKENS",
+		value: func(instance types.Instance, refs *ec2References) string {
+			if instance.MetadataOptions == nil {
+				return ""
+			}
+			return string(instance.MetadataOptions.HttpTokens)
+		},
+	},
+	{
+		name:   "vcpu",
+		header: "VCPU",
+		value: func(instance types.Instance, refs *ec2References) string {
+			spec, ok := refs.instanceTypes[string(instance.InstanceType)]
+			if !ok {
+				return ""
+			}
+			return strconv.Itoa(int(spec.VCpus))
+		},
+	},
+	{
+		name:   "memory",
+		header: "MEMORY",
+		value: func(instance types.Instance, refs *ec2References) string {
+			spec, ok := refs.instanceTypes[string(instance.InstanceType)]
+			if !ok {
+				return ""
+			}
+			return formatMemory(spec.MemoryMiB)
+		},
+	},
+	{
+		name:   "network",
+		header: "NETWORK",
+		value: func(instance types.Instance, refs *ec2References) string {
+			return refs.instanceTypes[string(instance.InstanceType)].NetworkPerformance
+		},
+	},
+	{
+		name:   "gpu",
+		header: "GPU",
+		value: func(instance types.Instance, refs *ec2References) string {
+			spec, ok := refs.instanceTypes[string(instance.InstanceType)]
+			if !ok {
+				return ""
+			}
+			return strconv.Itoa(int(spec.Gpus))
+		},
+	},
+	{
+		name:   "status",
+		header: "STATUS",
+		value: func(instance types.Instance, refs *ec2References) string {
+			status, ok := refs.instanceStatuses[*instance.InstanceId]
+			if !ok {
+				return ""
+			}
+			return formatInstanceStatusCheck(status)
+		},
+	},
+	{
+		name:   "events",
+		header: "EVENTS",
+		value: func(instance types.Instance, refs *ec2References) string {
+			status, ok := refs.instanceStatuses[*instance.InstanceId]
+			if !ok {
+				return ""
+			}
+			return formatInstanceEvents(status)
+		},
+	},
+}
+
 // ec2Cmd represents the ec2 command
 var ec2Cmd = &cobra.Command{
 	Use:   "ec2",
@@ -32,7 +215,24 @@ var ec2Cmd = &cobra.Command{
 				os.Exit(1)
 			}
 		}
-		outputs, err := getEc2Instances(newAwsConfig())
+		columns, err := cmd.Flags().GetStringSlice("columns")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		for _, column := range columns {
+			if _, ok := findEc2Column(column); !ok {
+				fmt.Printf("unknown column %s\n", column)
+				os.Exit(1)
+			}
+		}
+		cfg := newAwsConfig()
+		outputs, err := getEc2Instances(cfg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		refs, err := getEc2References(cfg, outputs, columns)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -42,7 +242,7 @@ var ec2Cmd = &cobra.Command{
 			fmt.Println(err)
 			os.Exit(1)
 		}
-		err = showEc2Instances(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		err = showEc2Instances(outputs, refs, tablewriter.NewWriter(os.Stdout), sortPosition, columns)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
@@ -52,9 +252,15 @@ var ec2Cmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(ec2Cmd)
+	var names []string
+	for _, c := range ec2Columns {
+		names = append(names, c.name)
+	}
+	ec2Cmd.Flags().StringSliceP("columns", "c", nil, "Additional columns ("+strings.Join(names, ",")+")")
 }
 
-func getEc2Instances(cfg aws.Config) ([]*ec2.DescribeInstancesOutput, error) {
+// getEc2Instances returns the instances that match all the filters.
+func getEc2Instances(cfg aws.Config, filters ...types.Filter) ([]*ec2.DescribeInstancesOutput, error) {
 	var outputs []*ec2.DescribeInstancesOutput
 	var err error
 	client := ec2.NewFromConfig(cfg)
@@ -63,6 +269,7 @@ func getEc2Instances(cfg aws.Config) ([]*ec2.DescribeInstancesOutput, error) {
 	}
 	for output.NextToken != nil {
 		output, err = client.DescribeInstances(context.TODO(), &ec2.DescribeInstancesInput{
+			Filters:    filters,
 			MaxResults: aws.Int32(ec2MaxResults),
 			NextToken:  output.NextToken,
 		})
@@ -74,8 +281,61 @@ func getEc2Instances(cfg aws.Config) ([]*ec2.DescribeInstancesOutput, error) {
 	return outputs, nil
 }
 
-func showEc2Instances(outputs []*ec2.DescribeInstancesOutput, table *tablewriter.Table, sortPosition int) error {
-	header := []string{"NAME", "ID", "TYPE", "PRIVATE_IP", "PUBLIC_IP", "STATE", "SECURITY_GROUP"}
+// getEc2References fetches only the resources that the selected columns refer to.
+func getEc2References(cfg aws.Config, outputs []*ec2.DescribeInstancesOutput, columns []string) (*ec2References, error) {
+	refs := &ec2References{now: time.Now()}
+	for _, column := range columns {
+		switch column {
+		case "vcpu", "memory", "network", "gpu":
+			if refs.instanceTypes != nil {
+				continue
+			}
+			specs, err := getInstanceTypeSpecs(cfg, getEc2InstanceTypes(outputs))
+			if err != nil {
+				return nil, err
+			}
+			refs.instanceTypes = specs
+		case "status", "events":
+			if refs.instanceStatuses != nil {
+				continue
+			}
+			statuses, err := getInstanceStatuses(cfg)
+			if err != nil {
+				return nil, err
+			}
+			refs.instanceStatuses = getInstanceStatusMap(statuses)
+		case "subnet":
+			subnets, err := getSubnets(cfg)
+			if err != nil {
+				return nil, err
+			}
+			refs.subnetNames = getSubnetNames(subnets)
+		case "vpc":
+			vpcs, err := getVpc(cfg)
+			if err != nil {
+				return nil, err
+			}
+			refs.vpcNames = getVpcNames(vpcs)
+		}
+	}
+	return refs, nil
+}
+
+// showEc2Instances shows the default columns followed by the optional columns in the given order.
+func showEc2Instances(outputs []*ec2.DescribeInstancesOutput, refs *ec2References, table *tablewriter.Table, sortPosition int, columns []string) error {
+	header := []string{"NAME", "ID", "TYPE", "PRIVATE_IP", "PUBLIC_IP", "STATE", "SECURITY_GROUP", "ASG"}
+	var selected []ec2Column
+	for _, name := range columns {
+		column, ok := findEc2Column(name)
+		if !ok {
+			return fmt.Errorf("unknown column %s", name)
+		}
+		selected = append(selected, column)
+		header = append(header, column.header)
+	}
+	if refs == nil {
+		refs = &ec2References{now: time.Now()}
+	}
 	if sortPosition > len(header) || 1 > sortPosition {
 		return fmt.Errorf("out of sort range number when using --sort option")
 	}
@@ -84,32 +344,28 @@ func showEc2Instances(outputs []*ec2.DescribeInstancesOutput, table *tablewriter
 	var records [][]string
 	for _, o := range outputs {
 		for _, r := range o.Reservations {
-			var privateIp, publicIp, name string
 			for _, instance := range r.Instances {
-				securityGroups := ""
+				var securityGroups []string
 				for _, sg := range instance.SecurityGroups {
-					securityGroups = securityGroups + fmt.Sprintf("%s(%s)", *sg.GroupName, *sg.GroupId)
-				}
-				if instance.PrivateIpAddress != nil {
-					privateIp = *instance.PrivateIpAddress
-				}
-				if instance.PublicIpAddress != nil {
-					publicIp = *instance.PublicIpAddress
+					securityGroups = append(securityGroups, formatNameId(*sg.GroupName, *sg.GroupId))
 				}
-				for _, t := range instance.Tags {
-					if *t.Key == "Name" {
-						name = *t.Value
-					}
-				}
-				records = append(records, []string{
+				privateIp := aws.ToString(instance.PrivateIpAddress)
+				publicIp := aws.ToString(instance.PublicIpAddress)
+				name := getNameTag(instance.Tags)
+				record := []string{
 					name,
 					*instance.InstanceId,
 					string(instance.InstanceType),
 					privateIp,
 					publicIp,
 					string(instance.State.Name),
-					securityGroups,
-				})
+					joinValues(securityGroups),
+					getAsgName(instance.Tags),
+				}
+				for _, column := range selected {
+					record = append(record, column.value(instance, refs))
+				}
+				records = append(records, record)
 			}
 		}
 	}
@@ -118,3 +374,46 @@ func showEc2Instances(outputs []*ec2.DescribeInstancesOutput, table *tablewriter
 	table.Render()
 	return nil
 }
+
+func findEc2Column(name string) (ec2Column, bool) {
+	for _, column := range ec2Columns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	return ec2Column{}, false
+}
+
+// formatAge formats a duration in its largest unit, such as "3d", "5h" or "12m".
+func formatAge(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// getEc2InstanceNames returns the Name tag of each instance ID.
+func getEc2InstanceNames(outputs []*ec2.DescribeInstancesOutput) map[string]string {
+	names := map[string]string{}
+	for _, o := range outputs {
+		for _, r := range o.Reservations {
+			for _, instance := range r.Instances {
+				names[*instance.InstanceId] = getNameTag(instance.Tags)
+			}
+		}
+	}
+	return names
+}
+
+// getAsgName returns the Auto Scaling group that launched the instance, or "" when it was launched otherwise.
+func getAsgName(tags []types.Tag) string {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == asgTagKey {
+			return aws.ToString(tag.Value)
+		}
+	}
+	return ""
+}
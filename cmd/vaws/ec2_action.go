@@ -0,0 +1,284 @@
+package vaws
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	ec2ActionStart  = "start"
+	ec2ActionStop   = "stop"
+	ec2ActionReboot = "reboot"
+	// ec2ActionMaxWait is how long --wait waits for the instances to reach the target state.
+	ec2ActionMaxWait = 10 * time.Minute
+)
+
+// ec2StartCmd represents the ec2 start command
+var ec2StartCmd = &cobra.Command{
+	Use:   "start [name|id]...",
+	Short: "Start EC2 instances.",
+	Long:  `Start EC2 instances matched by Name tags, instance IDs or --filter.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runEc2Action(cmd, args, ec2ActionStart)
+	},
+}
+
+// ec2StopCmd represents the ec2 stop command
+var ec2StopCmd = &cobra.Command{
+	Use:   "stop [name|id]...",
+	Short: "Stop EC2 instances.",
+	Long:  `Stop EC2 instances matched by Name tags, instance IDs or --filter.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runEc2Action(cmd, args, ec2ActionStop)
+	},
+}
+
+// ec2RebootCmd represents the ec2 reboot command
+var ec2RebootCmd = &cobra.Command{
+	Use:   "reboot [name|id]...",
+	Short: "Reboot EC2 instances.",
+	Long: `Reboot EC2 instances matched by Name tags, instance IDs or --filter.
+A reboot does not change the instance state, so --wait only waits until the status checks pass,
+which they may already do before the reboot takes effect.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		runEc2Action(cmd, args, ec2ActionReboot)
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{ec2StartCmd, ec2StopCmd, ec2RebootCmd} {
+		ec2Cmd.AddCommand(cmd)
+		cmd.Flags().StringArrayP("filter", "f", nil, "DescribeInstances filter such as tag:Env=dev or instance-type=t3.micro,t3.small")
+		cmd.Flags().BoolP("yes", "y", false, "Skip the confirmation")
+		cmd.Flags().Bool("dry-run", false, "Check the permissions without changing the instances")
+		cmd.Flags().BoolP("wait", "w", false, "Wait until the instances reach the target state")
+	}
+}
+
+func runEc2Action(cmd *cobra.Command, args []string, action string) {
+	profile, err := cmd.Flags().GetString("aws-profile")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if profile != "" {
+		err := os.Setenv("AWS_PROFILE", profile)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+	filterFlgs, err := cmd.Flags().GetStringArray("filter")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	yesFlg, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	dryRunFlg, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	waitFlg, err := cmd.Flags().GetBool("wait")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(args) == 0 && len(filterFlgs) == 0 {
+		fmt.Println("specify instance names, instance IDs or --filter")
+		os.Exit(1)
+	}
+	filters, err := parseEc2Filters(filterFlgs)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	cfg := newAwsConfig()
+	outputs, err := getEc2Instances(cfg, filters...)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	instances, err := selectEc2ActionInstances(outputs, args)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(instances) == 0 {
+		fmt.Println("no EC2 instance matches")
+		os.Exit(1)
+	}
+	selected := []*ec2.DescribeInstancesOutput{{Reservations: []types.Reservation{{Instances: instances}}}}
+	err = showEc2Instances(selected, nil, tablewriter.NewWriter(os.Stdout), 1, nil)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if !dryRunFlg && !yesFlg && !confirm(os.Stdin, os.Stdout, fmt.Sprintf("%s %d instance(s)?", action, len(instances))) {
+		fmt.Println("canceled")
+		return
+	}
+	var instanceIds []string
+	for _, instance := range instances {
+		instanceIds = append(instanceIds, *instance.InstanceId)
+	}
+	client := ec2.NewFromConfig(cfg)
+	err = executeEc2Action(client, action, instanceIds, dryRunFlg)
+	if dryRunFlg {
+		if !isDryRunSuccess(err) {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("dry run: %s would succeed\n", action)
+		return
+	}
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Printf("%s requested: %s\n", action, strings.Join(instanceIds, ","))
+	if waitFlg {
+		err = waitEc2Action(client, action, instanceIds)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s completed: %s\n", action, strings.Join(instanceIds, ","))
+	}
+}
+
+// parseEc2Filters converts "name=value1,value2" into DescribeInstances filters.
+func parseEc2Filters(flags []string) ([]types.Filter, error) {
+	var filters []types.Filter
+	for _, flag := range flags {
+		i := strings.Index(flag, "=")
+		if i < 1 || i == len(flag)-1 {
+			return nil, fmt.Errorf("invalid filter %s, use name=value", flag)
+		}
+		filters = append(filters, types.Filter{
+			Name:   aws.String(flag[:i]),
+			Values: strings.Split(flag[i+1:], ","),
+		})
+	}
+	return filters, nil
+}
+
+// selectEc2ActionInstances returns the instances matching any of the keys, or all instances when there is no key.
+// Terminated instances are never selected, and a key matching no other instance is an error so that a typo does not go unnoticed.
+func selectEc2ActionInstances(outputs []*ec2.DescribeInstancesOutput, keys []string) ([]types.Instance, error) {
+	var instances []types.Instance
+	if len(keys) == 0 {
+		for _, o := range outputs {
+			for _, r := range o.Reservations {
+				instances = append(instances, r.Instances...)
+			}
+		}
+	}
+	seen := map[string]bool{}
+	var unmatched []string
+	for _, key := range keys {
+		matched := false
+		for _, instance := range findEc2Instances(outputs, key) {
+			if instance.State != nil && instance.State.Name == types.InstanceStateNameTerminated {
+				continue
+			}
+			matched = true
+			if !seen[*instance.InstanceId] {
+				seen[*instance.InstanceId] = true
+				instances = append(instances, instance)
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, key)
+		}
+	}
+	if len(unmatched) > 0 {
+		return nil, fmt.Errorf("no EC2 instance matches %s", strings.Join(unmatched, ","))
+	}
+	var selected []types.Instance
+	for _, instance := range instances {
+		if instance.State != nil && instance.State.Name == types.InstanceStateNameTerminated {
+			continue
+		}
+		selected = append(selected, instance)
+	}
+	return selected, nil
+}
+
+// confirm asks the question and reports whether the answer is yes.
+func confirm(in io.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && answer == "" {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func executeEc2Action(client *ec2.Client, action string, instanceIds []string, dryRun bool) error {
+	var err error
+	switch action {
+	case ec2ActionStart:
+		_, err = client.StartInstances(context.TODO(), &ec2.StartInstancesInput{
+			DryRun:      aws.Bool(dryRun),
+			InstanceIds: instanceIds,
+		})
+	case ec2ActionStop:
+		_, err = client.StopInstances(context.TODO(), &ec2.StopInstancesInput{
+			DryRun:      aws.Bool(dryRun),
+			InstanceIds: instanceIds,
+		})
+	case ec2ActionReboot:
+		_, err = client.RebootInstances(context.TODO(), &ec2.RebootInstancesInput{
+			DryRun:      aws.Bool(dryRun),
+			InstanceIds: instanceIds,
+		})
+	default:
+		err = fmt.Errorf("unknown action %s", action)
+	}
+	return err
+}
+
+// isDryRunSuccess reports whether the error is the DryRunOperation error that EC2 returns when the request would have succeeded.
+func isDryRunSuccess(err error) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == "DryRunOperation"
+}
+
+// waitEc2Action waits for running after start, stopped after stop and passing status checks after reboot.
+// The status checks may pass before the reboot takes effect, so the wait after reboot does not mean that it finished.
+func waitEc2Action(client *ec2.Client, action string, instanceIds []string) error {
+	switch action {
+	case ec2ActionStart:
+		return ec2.NewInstanceRunningWaiter(client).Wait(context.TODO(), &ec2.DescribeInstancesInput{
+			InstanceIds: instanceIds,
+		}, ec2ActionMaxWait)
+	case ec2ActionStop:
+		return ec2.NewInstanceStoppedWaiter(client).Wait(context.TODO(), &ec2.DescribeInstancesInput{
+			InstanceIds: instanceIds,
+		}, ec2ActionMaxWait)
+	case ec2ActionReboot:
+		return ec2.NewInstanceStatusOkWaiter(client).Wait(context.TODO(), &ec2.DescribeInstanceStatusInput{
+			InstanceIds: instanceIds,
+		}, ec2ActionMaxWait)
+	}
+	return fmt.Errorf("unknown action %s", action)
+}
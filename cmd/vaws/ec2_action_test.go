@@ -0,0 +1,144 @@
+package vaws
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseEc2Filters(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   []string
+		want    []types.Filter
+		wantErr bool
+	}{
+		{
+			name:  "tag and multiple values",
+			flags: []string{"tag:Env=dev", "instance-type=t3.micro,t3.small"},
+			want: []types.Filter{
+				{
+					Name:   aws.String("tag:Env"),
+					Values: []string{"dev"},
+				},
+				{
+					Name:   aws.String("instance-type"),
+					Values: []string{"t3.micro", "t3.small"},
+				},
+			},
+		},
+		{
+			name:    "no value",
+			flags:   []string{"tag:Env="},
+			wantErr: true,
+		},
+		{
+			name:    "no name",
+			flags:   []string{"=dev"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEc2Filters(tt.flags)
+			if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%v\n", tt.want)
+				t.Errorf("\ninput:\n%v, %v\n", got, err)
+			}
+		})
+	}
+}
+
+func Test_selectEc2ActionInstances(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "all",
+			want: []string{"i-11111111", "i-22222222", "i-33333333"},
+		},
+		{
+			name: "name and id",
+			keys: []string{"web1", "i-33333333", "web1"},
+			want: []string{"i-11111111", "i-33333333"},
+		},
+		{
+			name:    "unknown",
+			keys:    []string{"db1"},
+			wantErr: true,
+		},
+		{
+			name:    "typo",
+			keys:    []string{"web1", "wbe2"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instances, err := selectEc2ActionInstances(testEventInstances, tt.keys)
+			var got []string
+			for _, instance := range instances {
+				got = append(got, *instance.InstanceId)
+			}
+			if (err != nil) != tt.wantErr || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%v\n", tt.want)
+				t.Errorf("\ninput:\n%v\n", got)
+			}
+		})
+	}
+}
+
+func Test_confirm(t *testing.T) {
+	tests := []struct {
+		name   string
+		answer string
+		want   bool
+	}{
+		{name: "yes", answer: "yes\n", want: true},
+		{name: "y", answer: "Y\n", want: true},
+		{name: "no", answer: "n\n", want: false},
+		{name: "empty", answer: "\n", want: false},
+		{name: "eof", answer: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if got := confirm(strings.NewReader(tt.answer), &out, "stop 1 instance(s)?"); got != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+			}
+			if out.String() != "stop 1 instance(s)? [y/N]: " {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\ninput:\n%s\n", out.String())
+			}
+		})
+	}
+}
+
+func Test_isDryRunSuccess(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "dry run operation", err: fmt.Errorf("operation error: %w", &smithy.GenericAPIError{Code: "DryRunOperation"}), want: true},
+		{name: "unauthorized", err: &smithy.GenericAPIError{Code: "UnauthorizedOperation"}, want: false},
+		{name: "nil", err: nil, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDryRunSuccess(tt.err); got != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+const instanceStatusMaxResults = 1000
+
+// ec2EventsCmd represents the ec2 events command
+var ec2EventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Show scheduled events of EC2 instances.",
+	Long: `Show scheduled events of EC2 instances, such as reboots and retirements.
+Completed and canceled events are not shown.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		cfg := newAwsConfig()
+		outputs, err := getEc2Instances(cfg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		statuses, err := getInstanceStatuses(cfg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showEc2Events(outputs, statuses, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	ec2Cmd.AddCommand(ec2EventsCmd)
+}
+
+// getInstanceStatuses returns the status checks and scheduled events of all instances including stopped ones.
+func getInstanceStatuses(cfg aws.Config) ([]*ec2.DescribeInstanceStatusOutput, error) {
+	var outputs []*ec2.DescribeInstanceStatusOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeInstanceStatus API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeInstanceStatus(context.TODO(), &ec2.DescribeInstanceStatusInput{
+		IncludeAllInstances: aws.Bool(true),
+		MaxResults:          aws.Int32(instanceStatusMaxResults),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeInstanceStatus(context.TODO(), &ec2.DescribeInstanceStatusInput{
+			IncludeAllInstances: aws.Bool(true),
+			MaxResults:          aws.Int32(instanceStatusMaxResults),
+			NextToken:           output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// getInstanceStatusMap returns the status of each instance ID.
+func getInstanceStatusMap(outputs []*ec2.DescribeInstanceStatusOutput) map[string]types.InstanceStatus {
+	statuses := map[string]types.InstanceStatus{}
+	for _, o := range outputs {
+		for _, status := range o.InstanceStatuses {
+			statuses[*status.InstanceId] = status
+		}
+	}
+	return statuses
+}
+
+func showEc2Events(outputs []*ec2.DescribeInstancesOutput, statuses []*ec2.DescribeInstanceStatusOutput, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"NAME", "ID", "EVENT ID", "CODE", "DESCRIPTION", "NOT BEFORE", "NOT AFTER"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	instanceNames := getEc2InstanceNames(outputs)
+	var records [][]string
+	for _, o := range statuses {
+		for _, status := range o.InstanceStatuses {
+			for _, event := range activeInstanceEvents(status) {
+				records = append(records, []string{
+					instanceNames[*status.InstanceId],
+					*status.InstanceId,
+					aws.ToString(event.InstanceEventId),
+					string(event.Code),
+					aws.ToString(event.Description),
+					formatEventTime(event.NotBefore),
+					formatEventTime(event.NotAfter),
+				})
+			}
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// activeInstanceEvents returns the scheduled events that are neither completed nor canceled.
+func activeInstanceEvents(status types.InstanceStatus) []types.InstanceStatusEvent {
+	var events []types.InstanceStatusEvent
+	for _, event := range status.Events {
+		description := aws.ToString(event.Description)
+		if strings.HasPrefix(description, "[Completed]") || strings.HasPrefix(description, "[Canceled]") {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// formatInstanceStatusCheck summarizes the system and instance status checks in the same way as the console.
+func formatInstanceStatusCheck(status types.InstanceStatus) string {
+	var summaries []types.SummaryStatus
+	for _, summary := range []*types.InstanceStatusSummary{status.SystemStatus, status.InstanceStatus} {
+		if summary != nil {
+			summaries = append(summaries, summary.Status)
+		}
+	}
+	passed := 0
+	for _, summary := range summaries {
+		switch summary {
+		case types.SummaryStatusInitializing:
+			return "initializing"
+		case types.SummaryStatusNotApplicable:
+			return ""
+		case types.SummaryStatusOk:
+			passed++
+		}
+	}
+	if len(summaries) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d checks passed", passed, len(summaries))
+}
+
+// formatInstanceEvents returns the active events as "code(not before)".
+func formatInstanceEvents(status types.InstanceStatus) string {
+	var events []string
+	for _, event := range activeInstanceEvents(status) {
+		events = append(events, fmt.Sprintf("%s(%s)", event.Code, formatEventTime(event.NotBefore)))
+	}
+	return joinValues(events)
+}
+
+func formatEventTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
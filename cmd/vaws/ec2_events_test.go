@@ -0,0 +1,154 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+	"time"
+)
+
+var testInstanceStatuses = []*ec2.DescribeInstanceStatusOutput{
+	{
+		InstanceStatuses: []types.InstanceStatus{
+			{
+				Events: []types.InstanceStatusEvent{
+					{
+						Code:            "instance-retirement",
+						Description:     aws.String("The instance is running on degraded hardware"),
+						InstanceEventId: aws.String("instance-event-11111111"),
+						NotAfter:        aws.Time(time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC)),
+						NotBefore:       aws.Time(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)),
+					},
+					{
+						Code:            "system-reboot",
+						Description:     aws.String("[Completed] Scheduled reboot"),
+						InstanceEventId: aws.String("instance-event-22222222"),
+						NotBefore:       aws.Time(time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+				InstanceId: aws.String("i-11111111"),
+				InstanceStatus: &types.InstanceStatusSummary{
+					Status: "ok",
+				},
+				SystemStatus: &types.InstanceStatusSummary{
+					Status: "impaired",
+				},
+			},
+			{
+				Events: []types.InstanceStatusEvent{
+					{
+						Code:            "system-reboot",
+						Description:     aws.String("Scheduled reboot"),
+						InstanceEventId: aws.String("instance-event-33333333"),
+						NotAfter:        aws.Time(time.Date(2022, 2, 20, 2, 0, 0, 0, time.UTC)),
+						NotBefore:       aws.Time(time.Date(2022, 2, 20, 0, 0, 0, 0, time.UTC)),
+					},
+				},
+				InstanceId: aws.String("i-22222222"),
+				InstanceStatus: &types.InstanceStatusSummary{
+					Status: "ok",
+				},
+				SystemStatus: &types.InstanceStatusSummary{
+					Status: "ok",
+				},
+			},
+			{
+				InstanceId: aws.String("i-33333333"),
+				InstanceStatus: &types.InstanceStatusSummary{
+					Status: "not-applicable",
+				},
+				SystemStatus: &types.InstanceStatusSummary{
+					Status: "not-applicable",
+				},
+			},
+		},
+	},
+}
+
+var testEventInstances = []*ec2.DescribeInstancesOutput{
+	{
+		Reservations: []types.Reservation{
+			{
+				Instances: []types.Instance{
+					{
+						InstanceId:   aws.String("i-11111111"),
+						InstanceType: "t3.small",
+						State: &types.InstanceState{
+							Name: "running",
+						},
+						Tags: []types.Tag{
+							{
+								Key:   aws.String("Name"),
+								Value: aws.String("web1"),
+							},
+						},
+					},
+					{
+						InstanceId:   aws.String("i-22222222"),
+						InstanceType: "t3.small",
+						State: &types.InstanceState{
+							Name: "running",
+						},
+						Tags: []types.Tag{
+							{
+								Key:   aws.String("Name"),
+								Value: aws.String("web2"),
+							},
+						},
+					},
+					{
+						InstanceId:   aws.String("i-33333333"),
+						InstanceType: "t3.small",
+						State: &types.InstanceState{
+							Name: "stopped",
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func Test_showEc2Events(t *testing.T) {
+	type args struct {
+		outputs      []*ec2.DescribeInstancesOutput
+		statuses     []*ec2.DescribeInstanceStatusOutput
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "sort by not before",
+			args: args{
+				outputs:      testEventInstances,
+				statuses:     testInstanceStatuses,
+				sortPosition: 6,
+			},
+			want: `+------+------------+-------------------------+---------------------+--------------------------------+----------------------+----------------------+
+| NAME |     ID     |        EVENT ID         |        CODE         |          DESCRIPTION           |      NOT BEFORE      |      NOT AFTER       |
++------+------------+-------------------------+---------------------+--------------------------------+----------------------+----------------------+
+| web2 | i-22222222 | instance-event-33333333 | system-reboot       | Scheduled reboot               | 2022-02-20T00:00:00Z | 2022-02-20T02:00:00Z |
+| web1 | i-11111111 | instance-event-11111111 | instance-retirement | The instance is running on     | 2022-03-01T00:00:00Z | 2022-03-15T00:00:00Z |
+|      |            |                         |                     | degraded hardware              |                      |                      |
++------+------------+-------------------------+---------------------+--------------------------------+----------------------+----------------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showEc2Events(tt.args.outputs, tt.args.statuses, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package vaws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+)
+
+// ec2ShowOutputs holds the instances matched by the ec2 show command and the resources they refer to.
+type ec2ShowOutputs struct {
+	instances []types.Instance
+	volumes   []*ec2.DescribeVolumesOutput
+	subnets   []*ec2.DescribeSubnetsOutput
+	vpcs      []*ec2.DescribeVpcsOutput
+}
+
+// ec2Detail is everything the ec2 show command shows about an instance.
+type ec2Detail struct {
+	Name               string                      `json:"name"`
+	InstanceId         string                      `json:"instanceId"`
+	State              string                      `json:"state"`
+	InstanceType       string                      `json:"instanceType"`
+	ImageId            string                      `json:"imageId"`
+	KeyName            string                      `json:"keyName"`
+	IamInstanceProfile string                      `json:"iamInstanceProfile"`
+	LaunchTime         string                      `json:"launchTime"`
+	Monitoring         string                      `json:"monitoring"`
+	MetadataHttpTokens string                      `json:"metadataHttpTokens"`
+	Placement          ec2DetailPlacement          `json:"placement"`
+	NetworkInterfaces  []ec2DetailNetworkInterface `json:"networkInterfaces"`
+	Volumes            []ec2DetailVolume           `json:"volumes"`
+	Tags               map[string]string           `json:"tags"`
+}
+
+type ec2DetailPlacement struct {
+	AvailabilityZone string `json:"availabilityZone"`
+	Subnet           string `json:"subnet"`
+	Vpc              string `json:"vpc"`
+	Tenancy          string `json:"tenancy"`
+	GroupName        string `json:"groupName"`
+}
+
+type ec2DetailNetworkInterface struct {
+	NetworkInterfaceId string   `json:"networkInterfaceId"`
+	DeviceIndex        int32    `json:"deviceIndex"`
+	Subnet             string   `json:"subnet"`
+	PrivateIps         []string `json:"privateIps"`
+	PublicIps          []string `json:"publicIps"`
+	SecurityGroups     []string `json:"securityGroups"`
+}
+
+type ec2DetailVolume struct {
+	VolumeId            string `json:"volumeId"`
+	Device              string `json:"device"`
+	Size                int32  `json:"size"`
+	VolumeType          string `json:"volumeType"`
+	Iops                int32  `json:"iops"`
+	Encrypted           bool   `json:"encrypted"`
+	DeleteOnTermination bool   `json:"deleteOnTermination"`
+}
+
+// ec2ShowCmd represents the ec2 show command
+var ec2ShowCmd = &cobra.Command{
+	Use:   "show <name|id>",
+	Short: "Show the details of EC2 instances.",
+	Long: `Show the details of EC2 instances.
+The argument is matched against the Name tag and the instance ID.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		output, err := cmd.Flags().GetString("output")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		outputs, err := getEc2ShowOutputs(newAwsConfig(), args[0])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showEc2Details(newEc2Details(outputs), os.Stdout, output)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	ec2Cmd.AddCommand(ec2ShowCmd)
+	ec2ShowCmd.Flags().StringP("output", "o", "text", "Output format (text or json)")
+}
+
+func getEc2ShowOutputs(cfg aws.Config, key string) (*ec2ShowOutputs, error) {
+	instances, err := getEc2Instances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs := &ec2ShowOutputs{
+		instances: findEc2Instances(instances, key),
+	}
+	if len(outputs.instances) == 0 {
+		return nil, fmt.Errorf("no EC2 instance matches %s", key)
+	}
+	var instanceIds []string
+	for _, instance := range outputs.instances {
+		instanceIds = append(instanceIds, *instance.InstanceId)
+	}
+	outputs.volumes, err = getEbsVolumes(cfg, types.Filter{
+		Name:   aws.String("attachment.instance-id"),
+		Values: instanceIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs.subnets, err = getSubnets(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.vpcs, err = getVpc(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// findEc2Instances returns the instances whose instance ID or Name tag equals the key.
+func findEc2Instances(outputs []*ec2.DescribeInstancesOutput, key string) []types.Instance {
+	var instances []types.Instance
+	for _, o := range outputs {
+		for _, r := range o.Reservations {
+			for _, instance := range r.Instances {
+				if *instance.InstanceId == key || getNameTag(instance.Tags) == key {
+					instances = append(instances, instance)
+				}
+			}
+		}
+	}
+	return instances
+}
+
+func newEc2Details(outputs *ec2ShowOutputs) []ec2Detail {
+	subnetNames := getSubnetNames(outputs.subnets)
+	vpcNames := getVpcNames(outputs.vpcs)
+	var details []ec2Detail
+	for _, instance := range outputs.instances {
+		detail := ec2Detail{
+			Name:         getNameTag(instance.Tags),
+			InstanceId:   *instance.InstanceId,
+			State:        string(instance.State.Name),
+			InstanceType: string(instance.InstanceType),
+			ImageId:      aws.ToString(instance.ImageId),
+			KeyName:      aws.ToString(instance.KeyName),
+			Tags:         map[string]string{},
+		}
+		if instance.IamInstanceProfile != nil {
+			detail.IamInstanceProfile = aws.ToString(instance.IamInstanceProfile.Arn)
+		}
+		if instance.LaunchTime != nil {
+			detail.LaunchTime = instance.LaunchTime.UTC().Format(time.RFC3339)
+		}
+		if instance.Monitoring != nil {
+			detail.Monitoring = string(instance.Monitoring.State)
+		}
+		if instance.MetadataOptions != nil {
+			detail.MetadataHttpTokens = string(instance.MetadataOptions.HttpTokens)
+		}
+		if instance.Placement != nil {
+			detail.Placement.AvailabilityZone = aws.ToString(instance.Placement.AvailabilityZone)
+			detail.Placement.Tenancy = string(instance.Placement.Tenancy)
+			detail.Placement.GroupName = aws.ToString(instance.Placement.GroupName)
+		}
+		if instance.SubnetId != nil {
+			detail.Placement.Subnet = formatNameId(subnetNames[*instance.SubnetId], *instance.SubnetId)
+		}
+		if instance.VpcId != nil {
+			detail.Placement.Vpc = formatNameId(vpcNames[*instance.VpcId], *instance.VpcId)
+		}
+		for _, eni := range instance.NetworkInterfaces {
+			detailEni := ec2DetailNetworkInterface{
+				NetworkInterfaceId: *eni.NetworkInterfaceId,
+			}
+			if eni.Attachment != nil && eni.Attachment.DeviceIndex != nil {
+				detailEni.DeviceIndex = *eni.Attachment.DeviceIndex
+			}
+			if eni.SubnetId != nil {
+				detailEni.Subnet = formatNameId(subnetNames[*eni.SubnetId], *eni.SubnetId)
+			}
+			for _, ip := range eni.PrivateIpAddresses {
+				detailEni.PrivateIps = append(detailEni.PrivateIps, aws.ToString(ip.PrivateIpAddress))
+				if ip.Association != nil && ip.Association.PublicIp != nil {
+					detailEni.PublicIps = append(detailEni.PublicIps, *ip.Association.PublicIp)
+				}
+			}
+			for _, sg := range eni.Groups {
+				detailEni.SecurityGroups = append(detailEni.SecurityGroups, formatNameId(*sg.GroupName, *sg.GroupId))
+			}
+			detail.NetworkInterfaces = append(detail.NetworkInterfaces, detailEni)
+		}
+		sort.Slice(detail.NetworkInterfaces, func(i, j int) bool {
+			return detail.NetworkInterfaces[i].DeviceIndex < detail.NetworkInterfaces[j].DeviceIndex
+		})
+		for _, o := range outputs.volumes {
+			for _, volume := range o.Volumes {
+				for _, attachment := range volume.Attachments {
+					if aws.ToString(attachment.InstanceId) != *instance.InstanceId {
+						continue
+					}
+					detail.Volumes = append(detail.Volumes, ec2DetailVolume{
+						VolumeId:            *volume.VolumeId,
+						Device:              aws.ToString(attachment.Device),
+						Size:                aws.ToInt32(volume.Size),
+						VolumeType:          string(volume.VolumeType),
+						Iops:                aws.ToInt32(volume.Iops),
+						Encrypted:           aws.ToBool(volume.Encrypted),
+						DeleteOnTermination: aws.ToBool(attachment.DeleteOnTermination),
+					})
+				}
+			}
+		}
+		sort.Slice(detail.Volumes, func(i, j int) bool { return detail.Volumes[i].Device < detail.Volumes[j].Device })
+		for _, tag := range instance.Tags {
+			detail.Tags[*tag.Key] = *tag.Value
+		}
+		details = append(details, detail)
+	}
+	return details
+}
+
+// showEc2Details writes the details as sectioned text or as a JSON array.
+func showEc2Details(details []ec2Detail, w io.Writer, output string) error {
+	switch output {
+	case "json":
+		b, err := json.MarshalIndent(details, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	case "text":
+		for i, detail := range details {
+			if i > 0 {
+				fmt.Fprintln(w)
+			}
+			writeEc2Detail(detail, w)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown output format %s, use text or json", output)
+}
+
+func writeEc2Detail(detail ec2Detail, w io.Writer) {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 8, 1, ' ', 0)
+	fmt.Fprintf(tw, "%s\n", formatNameId(detail.Name, detail.InstanceId))
+	fmt.Fprintf(tw, "[Instance]\n")
+	fmt.Fprintf(tw, "  State:\t%s\n", detail.State)
+	fmt.Fprintf(tw, "  Type:\t%s\n", detail.InstanceType)
+	fmt.Fprintf(tw, "  AMI:\t%s\n", detail.ImageId)
+	fmt.Fprintf(tw, "  Key Pair:\t%s\n", detail.KeyName)
+	fmt.Fprintf(tw, "  IAM Instance Profile:\t%s\n", detail.IamInstanceProfile)
+	fmt.Fprintf(tw, "  Launch Time:\t%s\n", detail.LaunchTime)
+	fmt.Fprintf(tw, "  Monitoring:\t%s\n", detail.Monitoring)
+	fmt.Fprintf(tw, "  Metadata Tokens:\t%s\n", ec2MetadataTokens(detail.MetadataHttpTokens))
+	fmt.Fprintf(tw, "[Placement]\n")
+	fmt.Fprintf(tw, "  Availability Zone:\t%s\n", detail.Placement.AvailabilityZone)
+	fmt.Fprintf(tw, "  Subnet:\t%s\n", detail.Placement.Subnet)
+	fmt.Fprintf(tw, "  VPC:\t%s\n", detail.Placement.Vpc)
+	fmt.Fprintf(tw, "  Tenancy:\t%s\n", detail.Placement.Tenancy)
+	fmt.Fprintf(tw, "  Placement Group:\t%s\n", detail.Placement.GroupName)
+	fmt.Fprintf(tw, "[Network Interfaces]\n")
+	for _, eni := range detail.NetworkInterfaces {
+		fmt.Fprintf(tw, "  %s:\tdevice %d, %s\n", eni.NetworkInterfaceId, eni.DeviceIndex, eni.Subnet)
+		fmt.Fprintf(tw, "    Private IP:\t%s\n", strings.Join(eni.PrivateIps, ","))
+		fmt.Fprintf(tw, "    Public IP:\t%s\n", strings.Join(eni.PublicIps, ","))
+		fmt.Fprintf(tw, "    Security Group:\t%s\n", strings.Join(eni.SecurityGroups, ","))
+	}
+	fmt.Fprintf(tw, "[Volumes]\n")
+	for _, volume := range detail.Volumes {
+		attributes := []string{volume.VolumeId, fmt.Sprintf("%d GiB", volume.Size), volume.VolumeType}
+		if volume.Iops > 0 {
+			attributes = append(attributes, fmt.Sprintf("%d IOPS", volume.Iops))
+		}
+		if volume.Encrypted {
+			attributes = append(attributes, "encrypted")
+		}
+		if volume.DeleteOnTermination {
+			attributes = append(attributes, "delete on termination")
+		}
+		fmt.Fprintf(tw, "  %s:\t%s\n", volume.Device, strings.Join(attributes, ", "))
+	}
+	fmt.Fprintf(tw, "[Tags]\n")
+	var keys []string
+	for key := range detail.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(tw, "  %s:\t%s\n", key, detail.Tags[key])
+	}
+	tw.Flush()
+	// tabwriter pads the label of an empty value
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+}
+
+// ec2MetadataTokens describes whether IMDSv2 is enforced.
+func ec2MetadataTokens(httpTokens string) string {
+	switch httpTokens {
+	case string(types.HttpTokensStateRequired):
+		return "required (IMDSv2 enforced)"
+	case string(types.HttpTokensStateOptional):
+		return "optional (IMDSv1 allowed)"
+	}
+	return httpTokens
+}
@@ -0,0 +1,295 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"testing"
+	"time"
+)
+
+var testEc2ShowOutputs = &ec2ShowOutputs{
+	instances: []types.Instance{
+		{
+			IamInstanceProfile: &types.IamInstanceProfile{
+				Arn: aws.String("arn:aws:iam::111111111111:instance-profile/web"),
+			},
+			ImageId:      aws.String("ami-11111111"),
+			InstanceId:   aws.String("i-11111111"),
+			InstanceType: "t3.micro",
+			KeyName:      aws.String("web-key"),
+			LaunchTime:   aws.Time(time.Date(2022, 2, 1, 9, 30, 0, 0, time.UTC)),
+			MetadataOptions: &types.InstanceMetadataOptionsResponse{
+				HttpTokens: "required",
+			},
+			Monitoring: &types.Monitoring{
+				State: "disabled",
+			},
+			NetworkInterfaces: []types.InstanceNetworkInterface{
+				{
+					Attachment: &types.InstanceNetworkInterfaceAttachment{
+						DeviceIndex: aws.Int32(1),
+					},
+					NetworkInterfaceId: aws.String("eni-22222222"),
+					PrivateIpAddresses: []types.InstancePrivateIpAddress{
+						{
+							PrivateIpAddress: aws.String("10.0.0.20"),
+						},
+					},
+					SubnetId: aws.String("subnet-aaaaaaaa"),
+				},
+				{
+					Attachment: &types.InstanceNetworkInterfaceAttachment{
+						DeviceIndex: aws.Int32(0),
+					},
+					Groups: []types.GroupIdentifier{
+						{
+							GroupId:   aws.String("sg-11111111"),
+							GroupName: aws.String("web"),
+						},
+					},
+					NetworkInterfaceId: aws.String("eni-11111111"),
+					PrivateIpAddresses: []types.InstancePrivateIpAddress{
+						{
+							Association: &types.InstanceNetworkInterfaceAssociation{
+								PublicIp: aws.String("203.0.113.10"),
+							},
+							PrivateIpAddress: aws.String("10.0.0.10"),
+						},
+						{
+							PrivateIpAddress: aws.String("10.0.0.11"),
+						},
+					},
+					SubnetId: aws.String("subnet-aaaaaaaa"),
+				},
+			},
+			Placement: &types.Placement{
+				AvailabilityZone: aws.String("ap-northeast-1a"),
+				GroupName:        aws.String(""),
+				Tenancy:          "default",
+			},
+			State: &types.InstanceState{
+				Name: "running",
+			},
+			SubnetId: aws.String("subnet-aaaaaaaa"),
+			Tags: []types.Tag{
+				{
+					Key:   aws.String("Name"),
+					Value: aws.String("web1"),
+				},
+				{
+					Key:   aws.String("Env"),
+					Value: aws.String("prod"),
+				},
+			},
+			VpcId: aws.String("vpc-12345678"),
+		},
+	},
+	volumes: []*ec2.DescribeVolumesOutput{
+		{
+			Volumes: []types.Volume{
+				{
+					Attachments: []types.VolumeAttachment{
+						{
+							DeleteOnTermination: aws.Bool(false),
+							Device:              aws.String("/dev/sdf"),
+							InstanceId:          aws.String("i-11111111"),
+						},
+					},
+					Encrypted:  aws.Bool(false),
+					Size:       aws.Int32(100),
+					VolumeId:   aws.String("vol-22222222"),
+					VolumeType: "st1",
+				},
+				{
+					Attachments: []types.VolumeAttachment{
+						{
+							DeleteOnTermination: aws.Bool(true),
+							Device:              aws.String("/dev/xvda"),
+							InstanceId:          aws.String("i-11111111"),
+						},
+					},
+					Encrypted:  aws.Bool(true),
+					Iops:       aws.Int32(3000),
+					Size:       aws.Int32(8),
+					VolumeId:   aws.String("vol-11111111"),
+					VolumeType: "gp3",
+				},
+			},
+		},
+	},
+	subnets: []*ec2.DescribeSubnetsOutput{
+		{
+			Subnets: []types.Subnet{
+				{
+					SubnetId: aws.String("subnet-aaaaaaaa"),
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("public-a"),
+						},
+					},
+				},
+			},
+		},
+	},
+	vpcs: []*ec2.DescribeVpcsOutput{
+		{
+			Vpcs: []types.Vpc{
+				{
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("app"),
+						},
+					},
+					VpcId: aws.String("vpc-12345678"),
+				},
+			},
+		},
+	},
+}
+
+func Test_showEc2Details(t *testing.T) {
+	type args struct {
+		outputs *ec2ShowOutputs
+		output  string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "text",
+			args: args{
+				outputs: testEc2ShowOutputs,
+				output:  "text",
+			},
+			want: `web1(i-11111111)
+[Instance]
+  State:                running
+  Type:                 t3.micro
+  AMI:                  ami-11111111
+  Key Pair:             web-key
+  IAM Instance Profile: arn:aws:iam::111111111111:instance-profile/web
+  Launch Time:          2022-02-01T09:30:00Z
+  Monitoring:           disabled
+  Metadata Tokens:      required (IMDSv2 enforced)
+[Placement]
+  Availability Zone: ap-northeast-1a
+  Subnet:            public-a(subnet-aaaaaaaa)
+  VPC:               app(vpc-12345678)
+  Tenancy:           default
+  Placement Group:
+[Network Interfaces]
+  eni-11111111:     device 0, public-a(subnet-aaaaaaaa)
+    Private IP:     10.0.0.10,10.0.0.11
+    Public IP:      203.0.113.10
+    Security Group: web(sg-11111111)
+  eni-22222222:     device 1, public-a(subnet-aaaaaaaa)
+    Private IP:     10.0.0.20
+    Public IP:
+    Security Group:
+[Volumes]
+  /dev/sdf:  vol-22222222, 100 GiB, st1
+  /dev/xvda: vol-11111111, 8 GiB, gp3, 3000 IOPS, encrypted, delete on termination
+[Tags]
+  Env:  prod
+  Name: web1
+`,
+		},
+		{
+			name: "json",
+			args: args{
+				outputs: testEc2ShowOutputs,
+				output:  "json",
+			},
+			want: `[
+  {
+    "name": "web1",
+    "instanceId": "i-11111111",
+    "state": "running",
+    "instanceType": "t3.micro",
+    "imageId": "ami-11111111",
+    "keyName": "web-key",
+    "iamInstanceProfile": "arn:aws:iam::111111111111:instance-profile/web",
+    "launchTime": "2022-02-01T09:30:00Z",
+    "monitoring": "disabled",
+    "metadataHttpTokens": "required",
+    "placement": {
+      "availabilityZone": "ap-northeast-1a",
+      "subnet": "public-a(subnet-aaaaaaaa)",
+      "vpc": "app(vpc-12345678)",
+      "tenancy": "default",
+      "groupName": ""
+    },
+    "networkInterfaces": [
+      {
+        "networkInterfaceId": "eni-11111111",
+        "deviceIndex": 0,
+        "subnet": "public-a(subnet-aaaaaaaa)",
+        "privateIps": [
+          "10.0.0.10",
+          "10.0.0.11"
+        ],
+        "publicIps": [
+          "203.0.113.10"
+        ],
+        "securityGroups": [
+          "web(sg-11111111)"
+        ]
+      },
+      {
+        "networkInterfaceId": "eni-22222222",
+        "deviceIndex": 1,
+        "subnet": "public-a(subnet-aaaaaaaa)",
+        "privateIps": [
+          "10.0.0.20"
+        ],
+        "publicIps": null,
+        "securityGroups": null
+      }
+    ],
+    "volumes": [
+      {
+        "volumeId": "vol-22222222",
+        "device": "/dev/sdf",
+        "size": 100,
+        "volumeType": "st1",
+        "iops": 0,
+        "encrypted": false,
+        "deleteOnTermination": false
+      },
+      {
+        "volumeId": "vol-11111111",
+        "device": "/dev/xvda",
+        "size": 8,
+        "volumeType": "gp3",
+        "iops": 3000,
+        "encrypted": true,
+        "deleteOnTermination": true
+      }
+    ],
+    "tags": {
+      "Env": "prod",
+      "Name": "web1"
+    }
+  }
+]
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showEc2Details(newEc2Details(tt.args.outputs), &buf, tt.args.output)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
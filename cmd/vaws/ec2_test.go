@@ -8,12 +8,16 @@ import (
 	"github.com/aws/smithy-go/middleware"
 	"github.com/olekukonko/tablewriter"
 	"testing"
+	"time"
 )
 
 func Test_printEc2Instances(t *testing.T) {
 	type args struct {
 		outputs      []*ec2.DescribeInstancesOutput
+		refs         *ec2References
 		sortPosition int
+		columns      []string
+		separator    string
 	}
 	tests := []struct {
 		name string
@@ -108,13 +112,13 @@ func Test_printEc2Instances(t *testing.T) {
 				},
 				sortPosition: 1,
 			},
-			want: `+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+
-| NAME  |         ID          |   TYPE   |  PRIVATE IP   |   PUBLIC IP   |  STATE  |            SECURITY GROUP             |
-+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+
-| app01 | i-06d4c29e4e5ccadc4 | t2.micro | 172.31.35.175 | 54.238.30.226 | running | launch-wizard-2(sg-0f0b4c4642ffb5ef2) |
-| app02 | i-06723a6629e542c50 | t3.small | 172.31.21.33  | 18.179.33.182 | running | launch-wizard-3(sg-08d35fef29987e75e) |
-| web01 | i-0abee92626b0a28a7 | t3.nano  | 172.31.18.8   | 35.73.127.100 | running | launch-wizard-1(sg-0d642190887707fd0) |
-+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+
+			want: `+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+-----+
+| NAME  |         ID          |   TYPE   |  PRIVATE IP   |   PUBLIC IP   |  STATE  |            SECURITY GROUP             | ASG |
++-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+-----+
+| app01 | i-06d4c29e4e5ccadc4 | t2.micro | 172.31.35.175 | 54.238.30.226 | running | launch-wizard-2(sg-0f0b4c4642ffb5ef2) |     |
+| app02 | i-06723a6629e542c50 | t3.small | 172.31.21.33  | 18.179.33.182 | running | launch-wizard-3(sg-08d35fef29987e75e) |     |
+| web01 | i-0abee92626b0a28a7 | t3.nano  | 172.31.18.8   | 35.73.127.100 | running | launch-wizard-1(sg-0d642190887707fd0) |     |
++-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+-----+
 `,
 		},
 		{
@@ -205,13 +209,13 @@ func Test_printEc2Instances(t *testing.T) {
 				},
 				sortPosition: 7,
 			},
-			want: `+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+
-| NAME  |         ID          |   TYPE   |  PRIVATE IP   |   PUBLIC IP   |  STATE  |            SECURITY GROUP             |
-+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+
-| web01 | i-0abee92626b0a28a7 | t3.nano  | 172.31.18.8   | 35.73.127.100 | running | launch-wizard-1(sg-0d642190887707fd0) |
-| app01 | i-06d4c29e4e5ccadc4 | t2.micro | 172.31.35.175 | 54.238.30.226 | running | launch-wizard-2(sg-0f0b4c4642ffb5ef2) |
-| app02 | i-06723a6629e542c50 | t3.small | 172.31.21.33  | 18.179.33.182 | running | launch-wizard-3(sg-08d35fef29987e75e) |
-+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+
+			want: `+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+-----+
+| NAME  |         ID          |   TYPE   |  PRIVATE IP   |   PUBLIC IP   |  STATE  |            SECURITY GROUP             | ASG |
++-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+-----+
+| web01 | i-0abee92626b0a28a7 | t3.nano  | 172.31.18.8   | 35.73.127.100 | running | launch-wizard-1(sg-0d642190887707fd0) |     |
+| app01 | i-06d4c29e4e5ccadc4 | t2.micro | 172.31.35.175 | 54.238.30.226 | running | launch-wizard-2(sg-0f0b4c4642ffb5ef2) |     |
+| app02 | i-06723a6629e542c50 | t3.small | 172.31.21.33  | 18.179.33.182 | running | launch-wizard-3(sg-08d35fef29987e75e) |     |
++-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+-----+
 `,
 		},
 		{
@@ -333,21 +337,314 @@ func Test_printEc2Instances(t *testing.T) {
 				},
 				sortPosition: 1,
 			},
-			want: `+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+
-| NAME  |         ID          |   TYPE   |  PRIVATE IP   |   PUBLIC IP   |  STATE  |            SECURITY GROUP             |
-+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+
-| app01 | i-06d4c29e4e5ccadc4 | t2.micro | 172.31.35.175 | 54.238.30.226 | running | launch-wizard-2(sg-0f0b4c4642ffb5ef2) |
-| app02 | i-06723a6629e542c50 | t3.small | 172.31.21.33  | 18.179.33.182 | running | launch-wizard-3(sg-08d35fef29987e75e) |
-| web01 | i-0abee92626b0a28a7 | t3.nano  | 172.31.18.8   | 35.73.127.100 | running | launch-wizard-1(sg-0d642190887707fd0) |
-| web02 | i-06d4c29e4e5edx5tf | t2.micro | 172.31.35.130 | 54.238.30.211 | running | launch-wizard-2(sg-0f0b4c4642ffb5ef2) |
-+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+
+			want: `+-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+-----+
+| NAME  |         ID          |   TYPE   |  PRIVATE IP   |   PUBLIC IP   |  STATE  |            SECURITY GROUP             | ASG |
++-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+-----+
+| app01 | i-06d4c29e4e5ccadc4 | t2.micro | 172.31.35.175 | 54.238.30.226 | running | launch-wizard-2(sg-0f0b4c4642ffb5ef2) |     |
+| app02 | i-06723a6629e542c50 | t3.small | 172.31.21.33  | 18.179.33.182 | running | launch-wizard-3(sg-08d35fef29987e75e) |     |
+| web01 | i-0abee92626b0a28a7 | t3.nano  | 172.31.18.8   | 35.73.127.100 | running | launch-wizard-1(sg-0d642190887707fd0) |     |
+| web02 | i-06d4c29e4e5edx5tf | t2.micro | 172.31.35.130 | 54.238.30.211 | running | launch-wizard-2(sg-0f0b4c4642ffb5ef2) |     |
++-------+---------------------+----------+---------------+---------------+---------+---------------------------------------+-----+
+`,
+		},
+		{
+			name: "optional columns",
+			args: args{
+				outputs: []*ec2.DescribeInstancesOutput{
+					{
+						Reservations: []types.Reservation{
+							{
+								Instances: []types.Instance{
+									{
+										Architecture:      "arm64",
+										ImageId:           aws.String("ami-11111111"),
+										InstanceId:        aws.String("i-11111111"),
+										InstanceLifecycle: "spot",
+										InstanceType:      "t4g.small",
+										KeyName:           aws.String("web-key"),
+										LaunchTime:        aws.Time(time.Date(2022, 2, 1, 9, 30, 0, 0, time.UTC)),
+										MetadataOptions: &types.InstanceMetadataOptionsResponse{
+											HttpTokens: "required",
+										},
+										Placement: &types.Placement{
+											AvailabilityZone: aws.String("ap-northeast-1a"),
+										},
+										PlatformDetails:  aws.String("Linux/UNIX"),
+										PrivateIpAddress: aws.String("10.0.0.10"),
+										State: &types.InstanceState{
+											Name: "running",
+										},
+										SubnetId: aws.String("subnet-aaaaaaaa"),
+										Tags: []types.Tag{
+											{
+												Key:   aws.String("Name"),
+												Value: aws.String("web1"),
+											},
+										},
+										VpcId: aws.String("vpc-12345678"),
+									},
+								},
+							},
+						},
+					},
+				},
+				refs: &ec2References{
+					subnetNames: map[string]string{"subnet-aaaaaaaa": "public-a"},
+					vpcNames:    map[string]string{},
+					now:         time.Date(2022, 2, 4, 12, 0, 0, 0, time.UTC),
+				},
+				sortPosition: 1,
+				columns:      []string{"az", "launch-time", "age", "platform", "arch", "key", "ami", "lifecycle", "subnet", "vpc", "imds"},
+			},
+			want: `+------+------------+-----------+------------+-----------+---------+----------------+-----+-----------------+----------------------+-----+------------+--------------+---------+--------------+-----------+---------------------------+--------------+-------------+
+| NAME |     ID     |   TYPE    | PRIVATE IP | PUBLIC IP |  STATE  | SECURITY GROUP | ASG |       AZ        |     LAUNCH TIME      | AGE |  PLATFORM  | ARCHITECTURE |   KEY   |     AMI      | LIFECYCLE |          SUBNET           |     VPC      | IMDS TOKENS |
++------+------------+-----------+------------+-----------+---------+----------------+-----+-----------------+----------------------+-----+------------+--------------+---------+--------------+-----------+---------------------------+--------------+-------------+
+| web1 | i-11111111 | t4g.small | 10.0.0.10  |           | running |                |     | ap-northeast-1a | 2022-02-01T09:30:00Z | 3d  | Linux/UNIX | arm64        | web-key | ami-11111111 | spot      | public-a(subnet-aaaaaaaa) | vpc-12345678 | required    |
++------+------------+-----------+------------+-----------+---------+----------------+-----+-----------------+----------------------+-----+------------+--------------+---------+--------------+-----------+---------------------------+--------------+-------------+
+`,
+		},
+		{
+			name: "multiple instances in a reservation",
+			args: args{
+				outputs: []*ec2.DescribeInstancesOutput{
+					{
+						Reservations: []types.Reservation{
+							{
+								Instances: []types.Instance{
+									{
+										InstanceId:       aws.String("i-11111111"),
+										InstanceType:     "t3.small",
+										PrivateIpAddress: aws.String("10.0.0.10"),
+										PublicIpAddress:  aws.String("203.0.113.10"),
+										SecurityGroups: []types.GroupIdentifier{
+											{
+												GroupId:   aws.String("sg-11111111"),
+												GroupName: aws.String("web"),
+											},
+											{
+												GroupId:   aws.String("sg-22222222"),
+												GroupName: aws.String("ssh"),
+											},
+										},
+										State: &types.InstanceState{
+											Name: "running",
+										},
+										Tags: []types.Tag{
+											{
+												Key:   aws.String("Name"),
+												Value: aws.String("web1"),
+											},
+										},
+									},
+									{
+										InstanceId:       aws.String("i-22222222"),
+										InstanceType:     "t3.small",
+										PrivateIpAddress: aws.String("10.0.0.11"),
+										SecurityGroups: []types.GroupIdentifier{
+											{
+												GroupId:   aws.String("sg-11111111"),
+												GroupName: aws.String("web"),
+											},
+										},
+										State: &types.InstanceState{
+											Name: "running",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				sortPosition: 2,
+			},
+			want: `+------+------------+----------+------------+--------------+---------+-----------------------------------+-----+
+| NAME |     ID     |   TYPE   | PRIVATE IP |  PUBLIC IP   |  STATE  |          SECURITY GROUP           | ASG |
++------+------------+----------+------------+--------------+---------+-----------------------------------+-----+
+| web1 | i-11111111 | t3.small | 10.0.0.10  | 203.0.113.10 | running | web(sg-11111111),ssh(sg-22222222) |     |
+|      | i-22222222 | t3.small | 10.0.0.11  |              | running | web(sg-11111111)                  |     |
++------+------------+----------+------------+--------------+---------+-----------------------------------+-----+
+`,
+		},
+		{
+			name: "newline separator",
+			args: args{
+				outputs: []*ec2.DescribeInstancesOutput{
+					{
+						Reservations: []types.Reservation{
+							{
+								Instances: []types.Instance{
+									{
+										InstanceId:       aws.String("i-11111111"),
+										InstanceType:     "t3.small",
+										PrivateIpAddress: aws.String("10.0.0.10"),
+										PublicIpAddress:  aws.String("203.0.113.10"),
+										SecurityGroups: []types.GroupIdentifier{
+											{
+												GroupId:   aws.String("sg-11111111"),
+												GroupName: aws.String("web"),
+											},
+											{
+												GroupId:   aws.String("sg-22222222"),
+												GroupName: aws.String("ssh"),
+											},
+										},
+										State: &types.InstanceState{
+											Name: "running",
+										},
+										Tags: []types.Tag{
+											{
+												Key:   aws.String("Name"),
+												Value: aws.String("web1"),
+											},
+										},
+									},
+									{
+										InstanceId:       aws.String("i-22222222"),
+										InstanceType:     "t3.small",
+										PrivateIpAddress: aws.String("10.0.0.11"),
+										SecurityGroups: []types.GroupIdentifier{
+											{
+												GroupId:   aws.String("sg-11111111"),
+												GroupName: aws.String("web"),
+											},
+										},
+										State: &types.InstanceState{
+											Name: "running",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				sortPosition: 2,
+				separator:    "\n",
+			},
+			want: `+------+------------+----------+------------+--------------+---------+------------------+-----+
+| NAME |     ID     |   TYPE   | PRIVATE IP |  PUBLIC IP   |  STATE  |  SECURITY GROUP  | ASG |
++------+------------+----------+------------+--------------+---------+------------------+-----+
+| web1 | i-11111111 | t3.small | 10.0.0.10  | 203.0.113.10 | running | web(sg-11111111) |     |
+|      |            |          |            |              |         | ssh(sg-22222222) |     |
+|      | i-22222222 | t3.small | 10.0.0.11  |              | running | web(sg-11111111) |     |
++------+------------+----------+------------+--------------+---------+------------------+-----+
+`,
+		},
+		{
+			name: "instance type columns",
+			args: args{
+				outputs: []*ec2.DescribeInstancesOutput{
+					{
+						Reservations: []types.Reservation{
+							{
+								Instances: []types.Instance{
+									{
+										InstanceId:       aws.String("i-11111111"),
+										InstanceType:     "g4dn.xlarge",
+										PrivateIpAddress: aws.String("10.0.0.10"),
+										State: &types.InstanceState{
+											Name: "running",
+										},
+									},
+									{
+										InstanceId:       aws.String("i-22222222"),
+										InstanceType:     "m5.large",
+										PrivateIpAddress: aws.String("10.0.0.11"),
+										State: &types.InstanceState{
+											Name: "running",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				refs: &ec2References{
+					instanceTypes: testInstanceTypeSpecs,
+				},
+				sortPosition: 2,
+				columns:      []string{"vcpu", "memory", "network", "gpu"},
+			},
+			want: `+------+------------+-------------+------------+-----------+---------+----------------+-----+------+--------+------------------+-----+
+| NAME |     ID     |    TYPE     | PRIVATE IP | PUBLIC IP |  STATE  | SECURITY GROUP | ASG | VCPU | MEMORY |     NETWORK      | GPU |
++------+------------+-------------+------------+-----------+---------+----------------+-----+------+--------+------------------+-----+
+|      | i-11111111 | g4dn.xlarge | 10.0.0.10  |           | running |                |     |    4 | 16 GiB | Up to 25 Gigabit |   1 |
+|      | i-22222222 | m5.large    | 10.0.0.11  |           | running |                |     |      |        |                  |     |
++------+------------+-------------+------------+-----------+---------+----------------+-----+------+--------+------------------+-----+
+`,
+		},
+		{
+			name: "asg",
+			args: args{
+				outputs: []*ec2.DescribeInstancesOutput{
+					{
+						Reservations: []types.Reservation{
+							{
+								Instances: []types.Instance{
+									{
+										InstanceId:       aws.String("i-11111111"),
+										InstanceType:     "t3.small",
+										PrivateIpAddress: aws.String("10.0.0.10"),
+										State: &types.InstanceState{
+											Name: "running",
+										},
+										Tags: []types.Tag{
+											{
+												Key:   aws.String("aws:autoscaling:groupName"),
+												Value: aws.String("web-asg"),
+											},
+										},
+									},
+									{
+										InstanceId:       aws.String("i-22222222"),
+										InstanceType:     "t3.small",
+										PrivateIpAddress: aws.String("10.0.0.11"),
+										State: &types.InstanceState{
+											Name: "running",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				sortPosition: 2,
+			},
+			want: `+------+------------+----------+------------+-----------+---------+----------------+---------+
+| NAME |     ID     |   TYPE   | PRIVATE IP | PUBLIC IP |  STATE  | SECURITY GROUP |   ASG   |
++------+------------+----------+------------+-----------+---------+----------------+---------+
+|      | i-11111111 | t3.small | 10.0.0.10  |           | running |                | web-asg |
+|      | i-22222222 | t3.small | 10.0.0.11  |           | running |                |         |
++------+------------+----------+------------+-----------+---------+----------------+---------+
+`,
+		},
+		{
+			name: "status columns",
+			args: args{
+				outputs: testEventInstances,
+				refs: &ec2References{
+					instanceStatuses: getInstanceStatusMap(testInstanceStatuses),
+				},
+				sortPosition: 2,
+				columns:      []string{"status", "events"},
+			},
+			want: `+------+------------+----------+------------+-----------+---------+----------------+-----+-------------------+-------------------------------------------+
+| NAME |     ID     |   TYPE   | PRIVATE IP | PUBLIC IP |  STATE  | SECURITY GROUP | ASG |      STATUS       |                  EVENTS                   |
++------+------------+----------+------------+-----------+---------+----------------+-----+-------------------+-------------------------------------------+
+| web1 | i-11111111 | t3.small |            |           | running |                |     | 1/2 checks passed | instance-retirement(2022-03-01T00:00:00Z) |
+| web2 | i-22222222 | t3.small |            |           | running |                |     | 2/2 checks passed | system-reboot(2022-02-20T00:00:00Z)       |
+|      | i-33333333 | t3.small |            |           | stopped |                |     |                   |                                           |
++------+------------+----------+------------+-----------+---------+----------------+-----+-------------------+-------------------------------------------+
 `,
 		},
 	}
 	for _, tt := range tests {
 		var buf bytes.Buffer
 		t.Run(tt.name, func(t *testing.T) {
-			showEc2Instances(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if tt.args.separator != "" {
+				valueSeparator = tt.args.separator
+				defer func() { valueSeparator = "," }()
+			}
+			showEc2Instances(tt.args.outputs, tt.args.refs, tablewriter.NewWriter(&buf), tt.args.sortPosition, tt.args.columns)
 			if buf.String() != tt.want {
 				t.Errorf("failed to test: %s\n", tt.name)
 				t.Errorf("\nwant:\n%s\n", tt.want)
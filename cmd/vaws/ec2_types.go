@@ -0,0 +1,287 @@
+package vaws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// instanceTypeMaxNames is the maximum number of instance types that the DescribeInstanceTypes API accepts at once.
+	instanceTypeMaxNames = 100
+	// instanceTypeCacheTTL is how long the specs in the local cache are used without calling the API.
+	instanceTypeCacheTTL = 30 * 24 * time.Hour
+)
+
+// instanceTypeSpec is the part of the instance type information that vaws shows and caches.
+type instanceTypeSpec struct {
+	VCpus              int32     `json:"vcpus"`
+	MemoryMiB          int64     `json:"memoryMiB"`
+	NetworkPerformance string    `json:"networkPerformance"`
+	Gpus               int32     `json:"gpus"`
+	FetchedAt          time.Time `json:"fetchedAt"`
+}
+
+// ec2TypesCmd represents the ec2 types command
+var ec2TypesCmd = &cobra.Command{
+	Use:   "types",
+	Short: "Show the capacity of EC2 instances by instance type.",
+	Long: `Show the capacity of EC2 instances by instance type.
+TOTAL VCPU and TOTAL MEMORY are the sums of the running instances.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		cfg := newAwsConfig()
+		outputs, err := getEc2Instances(cfg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		specs, err := getInstanceTypeSpecs(cfg, getEc2InstanceTypes(outputs))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showEc2Types(outputs, specs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	ec2Cmd.AddCommand(ec2TypesCmd)
+}
+
+// getEc2InstanceTypes returns the distinct instance types of the instances.
+func getEc2InstanceTypes(outputs []*ec2.DescribeInstancesOutput) []string {
+	seen := map[string]bool{}
+	var instanceTypes []string
+	for _, o := range outputs {
+		for _, r := range o.Reservations {
+			for _, instance := range r.Instances {
+				instanceType := string(instance.InstanceType)
+				if !seen[instanceType] {
+					seen[instanceType] = true
+					instanceTypes = append(instanceTypes, instanceType)
+				}
+			}
+		}
+	}
+	sort.Strings(instanceTypes)
+	return instanceTypes
+}
+
+// getInstanceTypeSpecs returns the specs of the instance types.
+// Only the types missing from the local cache or older than instanceTypeCacheTTL are described by the API.
+func getInstanceTypeSpecs(cfg aws.Config, instanceTypes []string) (map[string]instanceTypeSpec, error) {
+	cachePath, cacheErr := instanceTypeCachePath()
+	cache := map[string]instanceTypeSpec{}
+	if cacheErr == nil {
+		cache = loadInstanceTypeCache(cachePath)
+	}
+	now := time.Now()
+	var missing []string
+	for _, instanceType := range instanceTypes {
+		spec, ok := cache[instanceType]
+		if !ok || now.Sub(spec.FetchedAt) > instanceTypeCacheTTL {
+			missing = append(missing, instanceType)
+		}
+	}
+	if len(missing) > 0 {
+		fetched, err := describeInstanceTypes(cfg, missing, now)
+		if err != nil {
+			return nil, err
+		}
+		for instanceType, spec := range fetched {
+			cache[instanceType] = spec
+		}
+		if cacheErr == nil {
+			// The cache only saves API calls, so the command works even if it cannot be written
+			_ = saveInstanceTypeCache(cachePath, cache)
+		}
+	}
+	specs := map[string]instanceTypeSpec{}
+	for _, instanceType := range instanceTypes {
+		if spec, ok := cache[instanceType]; ok {
+			specs[instanceType] = spec
+		}
+	}
+	return specs, nil
+}
+
+func describeInstanceTypes(cfg aws.Config, instanceTypes []string, fetchedAt time.Time) (map[string]instanceTypeSpec, error) {
+	specs := map[string]instanceTypeSpec{}
+	client := ec2.NewFromConfig(cfg)
+	for start := 0; start < len(instanceTypes); start += instanceTypeMaxNames {
+		end := start + instanceTypeMaxNames
+		if end > len(instanceTypes) {
+			end = len(instanceTypes)
+		}
+		var names []types.InstanceType
+		for _, instanceType := range instanceTypes[start:end] {
+			names = append(names, types.InstanceType(instanceType))
+		}
+		// The DescribeInstanceTypes API executes the API once at the beginning because the NextToken "" is disallowed
+		output, err := client.DescribeInstanceTypes(context.TODO(), &ec2.DescribeInstanceTypesInput{
+			InstanceTypes: names,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range output.InstanceTypes {
+			specs[string(info.InstanceType)] = newInstanceTypeSpec(info, fetchedAt)
+		}
+		for output.NextToken != nil {
+			output, err = client.DescribeInstanceTypes(context.TODO(), &ec2.DescribeInstanceTypesInput{
+				InstanceTypes: names,
+				NextToken:     output.NextToken,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, info := range output.InstanceTypes {
+				specs[string(info.InstanceType)] = newInstanceTypeSpec(info, fetchedAt)
+			}
+		}
+	}
+	return specs, nil
+}
+
+func newInstanceTypeSpec(info types.InstanceTypeInfo, fetchedAt time.Time) instanceTypeSpec {
+	spec := instanceTypeSpec{FetchedAt: fetchedAt}
+	if info.VCpuInfo != nil {
+		spec.VCpus = aws.ToInt32(info.VCpuInfo.DefaultVCpus)
+	}
+	if info.MemoryInfo != nil {
+		spec.MemoryMiB = aws.ToInt64(info.MemoryInfo.SizeInMiB)
+	}
+	if info.NetworkInfo != nil {
+		spec.NetworkPerformance = aws.ToString(info.NetworkInfo.NetworkPerformance)
+	}
+	if info.GpuInfo != nil {
+		for _, gpu := range info.GpuInfo.Gpus {
+			spec.Gpus += aws.ToInt32(gpu.Count)
+		}
+	}
+	return spec
+}
+
+func instanceTypeCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vaws", "instance-types.json"), nil
+}
+
+// loadInstanceTypeCache returns an empty cache when the file does not exist or is broken.
+func loadInstanceTypeCache(path string) map[string]instanceTypeSpec {
+	cache := map[string]instanceTypeSpec{}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(b, &cache); err != nil {
+		return map[string]instanceTypeSpec{}
+	}
+	return cache
+}
+
+func saveInstanceTypeCache(path string, cache map[string]instanceTypeSpec) error {
+	b, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// showEc2Types shows the number of instances and their capacity for each instance type.
+func showEc2Types(outputs []*ec2.DescribeInstancesOutput, specs map[string]instanceTypeSpec, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"TYPE", "INSTANCES", "RUNNING", "VCPU", "MEMORY", "NETWORK", "GPU", "TOTAL VCPU", "TOTAL MEMORY"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	instances := map[string]int{}
+	running := map[string]int{}
+	for _, o := range outputs {
+		for _, r := range o.Reservations {
+			for _, instance := range r.Instances {
+				if instance.State != nil && instance.State.Name == types.InstanceStateNameTerminated {
+					continue
+				}
+				instances[string(instance.InstanceType)]++
+				if instance.State != nil && instance.State.Name == types.InstanceStateNameRunning {
+					running[string(instance.InstanceType)]++
+				}
+			}
+		}
+	}
+	var instanceTypes []string
+	for instanceType := range instances {
+		instanceTypes = append(instanceTypes, instanceType)
+	}
+	sort.Strings(instanceTypes)
+	var records [][]string
+	for _, instanceType := range instanceTypes {
+		count := instances[instanceType]
+		spec, ok := specs[instanceType]
+		if !ok {
+			records = append(records, []string{instanceType, strconv.Itoa(count), strconv.Itoa(running[instanceType]), "", "", "", "", "", ""})
+			continue
+		}
+		records = append(records, []string{
+			instanceType,
+			strconv.Itoa(count),
+			strconv.Itoa(running[instanceType]),
+			strconv.Itoa(int(spec.VCpus)),
+			formatMemory(spec.MemoryMiB),
+			spec.NetworkPerformance,
+			strconv.Itoa(int(spec.Gpus)),
+			strconv.Itoa(int(spec.VCpus) * running[instanceType]),
+			formatMemory(spec.MemoryMiB * int64(running[instanceType])),
+		})
+	}
+	// Keep the order of instance types among the same values
+	sort.SliceStable(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// formatMemory formats MiB in GiB, such as "0.5 GiB" or "16 GiB".
+func formatMemory(mib int64) string {
+	return strconv.FormatFloat(float64(mib)/1024, 'f', -1, 64) + " GiB"
+}
@@ -0,0 +1,144 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testInstanceTypeSpecs = map[string]instanceTypeSpec{
+	"t3.small": {
+		VCpus:              2,
+		MemoryMiB:          2048,
+		NetworkPerformance: "Up to 5 Gigabit",
+	},
+	"g4dn.xlarge": {
+		VCpus:              4,
+		MemoryMiB:          16384,
+		NetworkPerformance: "Up to 25 Gigabit",
+		Gpus:               1,
+	},
+}
+
+func Test_showEc2Types(t *testing.T) {
+	type args struct {
+		outputs      []*ec2.DescribeInstancesOutput
+		specs        map[string]instanceTypeSpec
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs: []*ec2.DescribeInstancesOutput{
+					{
+						Reservations: []types.Reservation{
+							{
+								Instances: []types.Instance{
+									{
+										InstanceId:   aws.String("i-11111111"),
+										InstanceType: "t3.small",
+										State: &types.InstanceState{
+											Name: "running",
+										},
+									},
+									{
+										InstanceId:   aws.String("i-22222222"),
+										InstanceType: "t3.small",
+										State: &types.InstanceState{
+											Name: "running",
+										},
+									},
+									{
+										InstanceId:   aws.String("i-33333333"),
+										InstanceType: "t3.small",
+										State: &types.InstanceState{
+											Name: "stopped",
+										},
+									},
+								},
+							},
+							{
+								Instances: []types.Instance{
+									{
+										InstanceId:   aws.String("i-44444444"),
+										InstanceType: "g4dn.xlarge",
+										State: &types.InstanceState{
+											Name: "running",
+										},
+									},
+									{
+										InstanceId:   aws.String("i-55555555"),
+										InstanceType: "m5.large",
+										State: &types.InstanceState{
+											Name: "running",
+										},
+									},
+									{
+										InstanceId:   aws.String("i-66666666"),
+										InstanceType: "c5.large",
+										State: &types.InstanceState{
+											Name: "terminated",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				specs:        testInstanceTypeSpecs,
+				sortPosition: 1,
+			},
+			want: `+-------------+-----------+---------+------+--------+------------------+-----+------------+--------------+
+|    TYPE     | INSTANCES | RUNNING | VCPU | MEMORY |     NETWORK      | GPU | TOTAL VCPU | TOTAL MEMORY |
++-------------+-----------+---------+------+--------+------------------+-----+------------+--------------+
+| g4dn.xlarge |         1 |       1 |    4 | 16 GiB | Up to 25 Gigabit |   1 |          4 | 16 GiB       |
+| m5.large    |         1 |       1 |      |        |                  |     |            |              |
+| t3.small    |         3 |       2 |    2 | 2 GiB  | Up to 5 Gigabit  |   0 |          4 | 4 GiB        |
++-------------+-----------+---------+------+--------+------------------+-----+------------+--------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showEc2Types(tt.args.outputs, tt.args.specs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
+
+func Test_instanceTypeCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "vaws", "instance-types.json")
+	if cache := loadInstanceTypeCache(path); len(cache) != 0 {
+		t.Errorf("failed to test: missing cache file\ninput: %v\n", cache)
+	}
+	cache := map[string]instanceTypeSpec{
+		"t3.small": {
+			VCpus:              2,
+			MemoryMiB:          2048,
+			NetworkPerformance: "Up to 5 Gigabit",
+			FetchedAt:          time.Date(2022, 2, 1, 9, 30, 0, 0, time.UTC),
+		},
+	}
+	if err := saveInstanceTypeCache(path, cache); err != nil {
+		t.Fatal(err)
+	}
+	if loaded := loadInstanceTypeCache(path); !reflect.DeepEqual(loaded, cache) {
+		t.Errorf("failed to test: saved cache\nwant: %v\ninput: %v\n", cache, loaded)
+	}
+}
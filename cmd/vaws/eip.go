@@ -0,0 +1,177 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+)
+
+// eipOutputs holds the responses of the APIs that the eip command combines.
+type eipOutputs struct {
+	addresses   *ec2.DescribeAddressesOutput
+	instances   []*ec2.DescribeInstancesOutput
+	natGateways []*ec2.DescribeNatGatewaysOutput
+}
+
+// eipCmd represents the eip command
+var eipCmd = &cobra.Command{
+	Use:   "eip",
+	Short: "Show Elastic IPs",
+	Long:  `Show Elastic IPs`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		outputs, err := getEips(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		unassociatedFlg, err := cmd.Flags().GetBool("unassociated")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showEips(outputs, tablewriter.NewWriter(os.Stdout), sortPosition, unassociatedFlg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(eipCmd)
+	eipCmd.Flags().BoolP("unassociated", "u", false, "Show only Elastic IPs that are not associated with any resource")
+}
+
+func getEips(cfg aws.Config) (*eipOutputs, error) {
+	var err error
+	outputs := &eipOutputs{}
+	outputs.addresses, err = getElasticIps(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.instances, err = getEc2Instances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.natGateways, err = getNatGateways(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+func getElasticIps(cfg aws.Config) (*ec2.DescribeAddressesOutput, error) {
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeAddresses API has no pagination
+	output, err := client.DescribeAddresses(context.TODO(), &ec2.DescribeAddressesInput{})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// showEips shows Elastic IPs and the resources they are associated with.
+// Unassociated Elastic IPs are charged while idle, so unassociatedFlg narrows the list down to them.
+func showEips(outputs *eipOutputs, table *tablewriter.Table, sortPosition int, unassociatedFlg bool) error {
+	header := []string{"PUBLIC IP", "ALLOCATION ID", "ASSOCIATION", "DOMAIN", "NETWORK BORDER GROUP", "TAGS"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	instanceNames := getEc2InstanceNames(outputs.instances)
+	// allocation ID -> NAT gateway ID
+	natGateways := map[string]string{}
+	for _, o := range outputs.natGateways {
+		for _, nat := range o.NatGateways {
+			// Deleted and failed NAT gateways keep their addresses in the response after releasing them
+			if nat.State != types.NatGatewayStateAvailable && nat.State != types.NatGatewayStatePending {
+				continue
+			}
+			for _, address := range nat.NatGatewayAddresses {
+				if address.AllocationId != nil {
+					natGateways[*address.AllocationId] = *nat.NatGatewayId
+				}
+			}
+		}
+	}
+	var records [][]string
+	if outputs.addresses != nil {
+		for _, eip := range outputs.addresses.Addresses {
+			association := eipAssociation(eip, instanceNames, natGateways)
+			if unassociatedFlg && association != "" {
+				continue
+			}
+			allocationId := ""
+			if eip.AllocationId != nil {
+				allocationId = *eip.AllocationId
+			}
+			networkBorderGroup := ""
+			if eip.NetworkBorderGroup != nil {
+				networkBorderGroup = *eip.NetworkBorderGroup
+			}
+			records = append(records, []string{
+				*eip.PublicIp,
+				allocationId,
+				association,
+				string(eip.Domain),
+				networkBorderGroup,
+				formatTags(eip.Tags),
+			})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// eipAssociation returns the resource that the Elastic IP is associated with, or "" when it is idle.
+// The NAT gateways only label an association, because an Elastic IP without an association is idle whatever refers to it.
+func eipAssociation(eip types.Address, instanceNames map[string]string, natGateways map[string]string) string {
+	if aws.ToString(eip.AssociationId) == "" && aws.ToString(eip.NetworkInterfaceId) == "" && aws.ToString(eip.InstanceId) == "" {
+		return ""
+	}
+	if eip.InstanceId != nil && *eip.InstanceId != "" {
+		return "instance " + formatNameId(instanceNames[*eip.InstanceId], *eip.InstanceId)
+	}
+	if eip.AllocationId != nil {
+		if nat, ok := natGateways[*eip.AllocationId]; ok {
+			return "NAT " + nat
+		}
+	}
+	return aws.ToString(eip.NetworkInterfaceId)
+}
+
+// formatTags returns the tags as "key=value" in key order.
+func formatTags(tags []types.Tag) string {
+	var pairs []string
+	for _, tag := range tags {
+		pairs = append(pairs, *tag.Key+"="+*tag.Value)
+	}
+	sort.Strings(pairs)
+	return joinValues(pairs)
+}
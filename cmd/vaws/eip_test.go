@@ -0,0 +1,144 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+var testEipOutputs = &eipOutputs{
+	addresses: &ec2.DescribeAddressesOutput{
+		Addresses: []types.Address{
+			{
+				AllocationId:       aws.String("eipalloc-11111111"),
+				AssociationId:      aws.String("eipassoc-11111111"),
+				Domain:             "vpc",
+				InstanceId:         aws.String("i-11111111"),
+				NetworkBorderGroup: aws.String("ap-northeast-1"),
+				NetworkInterfaceId: aws.String("eni-11111111"),
+				PublicIp:           aws.String("203.0.113.10"),
+				Tags: []types.Tag{
+					{
+						Key:   aws.String("Name"),
+						Value: aws.String("web1"),
+					},
+					{
+						Key:   aws.String("Env"),
+						Value: aws.String("prod"),
+					},
+				},
+			},
+			{
+				AllocationId:       aws.String("eipalloc-22222222"),
+				AssociationId:      aws.String("eipassoc-22222222"),
+				Domain:             "vpc",
+				NetworkBorderGroup: aws.String("ap-northeast-1"),
+				NetworkInterfaceId: aws.String("eni-22222222"),
+				PublicIp:           aws.String("203.0.113.5"),
+			},
+			{
+				AllocationId:       aws.String("eipalloc-33333333"),
+				AssociationId:      aws.String("eipassoc-33333333"),
+				Domain:             "vpc",
+				NetworkBorderGroup: aws.String("ap-northeast-1"),
+				NetworkInterfaceId: aws.String("eni-33333333"),
+				PublicIp:           aws.String("203.0.113.20"),
+			},
+			{
+				AllocationId:       aws.String("eipalloc-44444444"),
+				Domain:             "vpc",
+				NetworkBorderGroup: aws.String("ap-northeast-1"),
+				PublicIp:           aws.String("203.0.113.30"),
+				Tags: []types.Tag{
+					{
+						Key:   aws.String("Name"),
+						Value: aws.String("old-bastion"),
+					},
+				},
+			},
+		},
+	},
+	instances: testEniOutputs.instances,
+	natGateways: []*ec2.DescribeNatGatewaysOutput{
+		{
+			NatGateways: []types.NatGateway{
+				{
+					NatGatewayAddresses: []types.NatGatewayAddress{
+						{
+							AllocationId: aws.String("eipalloc-22222222"),
+						},
+					},
+					NatGatewayId: aws.String("nat-12345678"),
+					State:        types.NatGatewayStateAvailable,
+				},
+				{
+					NatGatewayAddresses: []types.NatGatewayAddress{
+						{
+							AllocationId: aws.String("eipalloc-44444444"),
+						},
+					},
+					NatGatewayId: aws.String("nat-87654321"),
+					State:        types.NatGatewayStateDeleted,
+				},
+			},
+		},
+	},
+}
+
+func Test_showEips(t *testing.T) {
+	type args struct {
+		outputs         *eipOutputs
+		sortPosition    int
+		unassociatedFlg bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs:      testEipOutputs,
+				sortPosition: 2,
+			},
+			want: `+--------------+-------------------+---------------------------+--------+----------------------+--------------------+
+|  PUBLIC IP   |   ALLOCATION ID   |        ASSOCIATION        | DOMAIN | NETWORK BORDER GROUP |        TAGS        |
++--------------+-------------------+---------------------------+--------+----------------------+--------------------+
+| 203.0.113.10 | eipalloc-11111111 | instance web1(i-11111111) | vpc    | ap-northeast-1       | Env=prod,Name=web1 |
+| 203.0.113.5  | eipalloc-22222222 | NAT nat-12345678          | vpc    | ap-northeast-1       |                    |
+| 203.0.113.20 | eipalloc-33333333 | eni-33333333              | vpc    | ap-northeast-1       |                    |
+| 203.0.113.30 | eipalloc-44444444 |                           | vpc    | ap-northeast-1       | Name=old-bastion   |
++--------------+-------------------+---------------------------+--------+----------------------+--------------------+
+`,
+		},
+		{
+			name: "unassociated",
+			args: args{
+				outputs:         testEipOutputs,
+				sortPosition:    1,
+				unassociatedFlg: true,
+			},
+			want: `+--------------+-------------------+-------------+--------+----------------------+------------------+
+|  PUBLIC IP   |   ALLOCATION ID   | ASSOCIATION | DOMAIN | NETWORK BORDER GROUP |       TAGS       |
++--------------+-------------------+-------------+--------+----------------------+------------------+
+| 203.0.113.30 | eipalloc-44444444 |             | vpc    | ap-northeast-1       | Name=old-bastion |
++--------------+-------------------+-------------+--------+----------------------+------------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showEips(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition, tt.args.unassociatedFlg)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
@@ -4,13 +4,37 @@ import (
 	"context"
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	classicTypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"os"
 	"sort"
 )
 
+const (
+	elbPageSize = 400
+	// elbTypeClassic is the TYPE of Classic Load Balancers, which the elasticloadbalancingv2 API does not return.
+	elbTypeClassic = "classic"
+)
+
+// elbOutputs holds the load balancers of the elasticloadbalancingv2 API and the Classic Load Balancers.
+type elbOutputs struct {
+	loadBalancers        *elasticloadbalancingv2.DescribeLoadBalancersOutput
+	classicLoadBalancers []classicTypes.LoadBalancerDescription
+}
+
+// elbListenerOutputs holds the listeners and rules of the load balancers that the elb sub commands walk through.
+type elbListenerOutputs struct {
+	loadBalancers []types.LoadBalancer
+	// load balancer ARN -> listeners
+	listeners map[string][]types.Listener
+	// listener ARN -> rules
+	rules map[string][]types.Rule
+}
+
 // elbCmd represents the elb command
 var elbCmd = &cobra.Command{
 	Use:   "elb",
@@ -34,12 +58,12 @@ var elbCmd = &cobra.Command{
 			fmt.Println(err)
 			os.Exit(1)
 		}
-		output, err := getElb(newAwsConfig())
+		outputs, err := getElbOutputs(newAwsConfig())
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
-		showElb(output, tablewriter.NewWriter(os.Stdout), sortPosition)
+		showElb(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
 	},
 }
 
@@ -47,6 +71,20 @@ func init() {
 	rootCmd.AddCommand(elbCmd)
 }
 
+func getElbOutputs(cfg aws.Config) (*elbOutputs, error) {
+	var err error
+	outputs := &elbOutputs{}
+	outputs.loadBalancers, err = getElb(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.classicLoadBalancers, err = getClassicElb(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
 func getElb(cfg aws.Config) (*elasticloadbalancingv2.DescribeLoadBalancersOutput, error) {
 	client := elasticloadbalancingv2.NewFromConfig(cfg)
 	output, err := client.DescribeLoadBalancers(context.TODO(), &elasticloadbalancingv2.DescribeLoadBalancersInput{})
@@ -57,7 +95,31 @@ func getElb(cfg aws.Config) (*elasticloadbalancingv2.DescribeLoadBalancersOutput
 	return output, nil
 }
 
-func showElb(output *elasticloadbalancingv2.DescribeLoadBalancersOutput, table *tablewriter.Table, sortPosition int) error {
+func getClassicElb(cfg aws.Config) ([]classicTypes.LoadBalancerDescription, error) {
+	var loadBalancers []classicTypes.LoadBalancerDescription
+	client := elasticloadbalancing.NewFromConfig(cfg)
+	// The DescribeLoadBalancers API executes the API once at the beginning because the Marker "" is disallowed
+	output, err := client.DescribeLoadBalancers(context.TODO(), &elasticloadbalancing.DescribeLoadBalancersInput{
+		PageSize: aws.Int32(elbPageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	loadBalancers = append(loadBalancers, output.LoadBalancerDescriptions...)
+	for output.NextMarker != nil {
+		output, err = client.DescribeLoadBalancers(context.TODO(), &elasticloadbalancing.DescribeLoadBalancersInput{
+			Marker:   output.NextMarker,
+			PageSize: aws.Int32(elbPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		loadBalancers = append(loadBalancers, output.LoadBalancerDescriptions...)
+	}
+	return loadBalancers, nil
+}
+
+func showElb(outputs *elbOutputs, table *tablewriter.Table, sortPosition int) error {
 	header := []string{"LB", "TYPE", "SCHEME", "VPC", "SUBNET", "SECURITY GROUP", "IP TYPE", "DNS NAME"}
 	if sortPosition > len(header) || 1 > sortPosition {
 		return fmt.Errorf("out of sort range number when using --sort option")
@@ -65,37 +127,139 @@ func showElb(output *elasticloadbalancingv2.DescribeLoadBalancersOutput, table *
 	recordIndex := sortPosition - 1
 	table.SetHeader(header)
 	var records [][]string
-	for _, lb := range output.LoadBalancers {
+	var loadBalancers []types.LoadBalancer
+	if outputs.loadBalancers != nil {
+		loadBalancers = outputs.loadBalancers.LoadBalancers
+	}
+	for _, lb := range loadBalancers {
 		name := *lb.LoadBalancerName
 		lbType := lb.Type
 		scheme := lb.Scheme
 		vpc := lb.VpcId
-		subnet := ""
-		for i, zone := range lb.AvailabilityZones {
-			if i == 0 {
-				subnet = *zone.SubnetId
-			} else {
-				subnet = fmt.Sprintf("%s,%s", subnet, *zone.SubnetId)
-			}
+		var subnets []string
+		for _, zone := range lb.AvailabilityZones {
+			subnets = append(subnets, *zone.SubnetId)
 		}
+		subnet := joinValues(subnets)
 		securityGroup := ""
 		if lb.SecurityGroups != nil {
-			for i, sg := range lb.SecurityGroups {
-				if i == 0 {
-					securityGroup = sg
-				} else {
-					securityGroup = fmt.Sprintf("%s,%s", securityGroup, sg)
-				}
-			}
-		} else { // for classic EC2
+			securityGroup = joinValues(lb.SecurityGroups)
+		} else { // Network Load Balancers may have no security group
 			securityGroup = "none"
 		}
 		ipType := lb.IpAddressType
 		dnsName := *lb.DNSName
 		records = append(records, []string{name, string(lbType), string(scheme), *vpc, subnet, securityGroup, string(ipType), dnsName})
 	}
+	for _, lb := range outputs.classicLoadBalancers {
+		securityGroup := "none"
+		if lb.SecurityGroups != nil {
+			securityGroup = joinValues(lb.SecurityGroups)
+		}
+		// Classic Load Balancers in a VPC support only IPv4
+		records = append(records, []string{*lb.LoadBalancerName, elbTypeClassic, aws.ToString(lb.Scheme), aws.ToString(lb.VPCId), joinValues(lb.Subnets), securityGroup, "ipv4", aws.ToString(lb.DNSName)})
+	}
 	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
 	table.AppendBulk(records)
 	table.Render()
 	return nil
 }
+
+// getElbListenerOutputs returns the listeners and rules of the load balancer named name, or of all load balancers when name is "".
+// Classic Load Balancers are not included, so the callers decide whether no match is an error.
+func getElbListenerOutputs(cfg aws.Config, name string) (*elbListenerOutputs, error) {
+	output, err := getElb(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs := &elbListenerOutputs{
+		listeners: map[string][]types.Listener{},
+		rules:     map[string][]types.Rule{},
+	}
+	for _, lb := range output.LoadBalancers {
+		if name == "" || aws.ToString(lb.LoadBalancerName) == name {
+			outputs.loadBalancers = append(outputs.loadBalancers, lb)
+		}
+	}
+	for _, lb := range outputs.loadBalancers {
+		listeners, err := getElbListeners(cfg, *lb.LoadBalancerArn)
+		if err != nil {
+			return nil, err
+		}
+		outputs.listeners[*lb.LoadBalancerArn] = listeners
+		// Only Application Load Balancers have rules
+		if lb.Type != types.LoadBalancerTypeEnumApplication {
+			continue
+		}
+		for _, listener := range listeners {
+			rules, err := getElbRules(cfg, *listener.ListenerArn)
+			if err != nil {
+				return nil, err
+			}
+			outputs.rules[*listener.ListenerArn] = rules
+		}
+	}
+	return outputs, nil
+}
+
+func getElbListeners(cfg aws.Config, loadBalancerArn string) ([]types.Listener, error) {
+	var listeners []types.Listener
+	client := elasticloadbalancingv2.NewFromConfig(cfg)
+	// The DescribeListeners API executes the API once at the beginning because the Marker "" is disallowed
+	output, err := client.DescribeListeners(context.TODO(), &elasticloadbalancingv2.DescribeListenersInput{
+		LoadBalancerArn: aws.String(loadBalancerArn),
+		PageSize:        aws.Int32(elbPageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	listeners = append(listeners, output.Listeners...)
+	for output.NextMarker != nil {
+		output, err = client.DescribeListeners(context.TODO(), &elasticloadbalancingv2.DescribeListenersInput{
+			LoadBalancerArn: aws.String(loadBalancerArn),
+			Marker:          output.NextMarker,
+			PageSize:        aws.Int32(elbPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, output.Listeners...)
+	}
+	return listeners, nil
+}
+
+func getElbRules(cfg aws.Config, listenerArn string) ([]types.Rule, error) {
+	var rules []types.Rule
+	client := elasticloadbalancingv2.NewFromConfig(cfg)
+	// The DescribeRules API executes the API once at the beginning because the Marker "" is disallowed
+	output, err := client.DescribeRules(context.TODO(), &elasticloadbalancingv2.DescribeRulesInput{
+		ListenerArn: aws.String(listenerArn),
+		PageSize:    aws.Int32(elbPageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	rules = append(rules, output.Rules...)
+	for output.NextMarker != nil {
+		output, err = client.DescribeRules(context.TODO(), &elasticloadbalancingv2.DescribeRulesInput{
+			ListenerArn: aws.String(listenerArn),
+			Marker:      output.NextMarker,
+			PageSize:    aws.Int32(elbPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, output.Rules...)
+	}
+	return rules, nil
+}
+
+// formatListener formats a listener as "protocol:port" such as "HTTPS:443".
+func formatListener(listener types.Listener) string {
+	return fmt.Sprintf("%s:%d", listener.Protocol, aws.ToInt32(listener.Port))
+}
+
+// formatClassicListener formats a listener of a Classic Load Balancer as "protocol:port" such as "HTTP:80".
+func formatClassicListener(listener classicTypes.Listener) string {
+	return fmt.Sprintf("%s:%d", aws.ToString(listener.Protocol), listener.LoadBalancerPort)
+}
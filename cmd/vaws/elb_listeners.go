@@ -0,0 +1,279 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// elbListenersOutputs holds the listeners and rules with all the certificates of the listeners.
+type elbListenersOutputs struct {
+	*elbListenerOutputs
+	// listener ARN -> certificates
+	certificates map[string][]types.Certificate
+}
+
+// elbListenersCmd represents the elb listeners command
+var elbListenersCmd = &cobra.Command{
+	Use:   "listeners [lb]",
+	Short: "Show the listeners of load balancers and their rules.",
+	Long: `Show the listeners of load balancers and their rules.
+The rules of each listener are shown in evaluation order, and the default rule comes last.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		outputs, err := getElbListenersOutputs(newAwsConfig(), name)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showElbListeners(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	elbCmd.AddCommand(elbListenersCmd)
+}
+
+func getElbListenersOutputs(cfg aws.Config, name string) (*elbListenersOutputs, error) {
+	listenerOutputs, err := getElbListenerOutputs(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(listenerOutputs.loadBalancers) == 0 && name != "" {
+		return nil, fmt.Errorf("no load balancer named %s", name)
+	}
+	outputs := &elbListenersOutputs{
+		elbListenerOutputs: listenerOutputs,
+		certificates:       map[string][]types.Certificate{},
+	}
+	for _, listeners := range outputs.listeners {
+		for _, listener := range listeners {
+			// DescribeListeners returns only the default certificate
+			if len(listener.Certificates) == 0 {
+				continue
+			}
+			outputs.certificates[*listener.ListenerArn], err = getElbListenerCertificates(cfg, *listener.ListenerArn)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return outputs, nil
+}
+
+func getElbListenerCertificates(cfg aws.Config, listenerArn string) ([]types.Certificate, error) {
+	var certificates []types.Certificate
+	client := elasticloadbalancingv2.NewFromConfig(cfg)
+	// The DescribeListenerCertificates API executes the API once at the beginning because the Marker "" is disallowed
+	output, err := client.DescribeListenerCertificates(context.TODO(), &elasticloadbalancingv2.DescribeListenerCertificatesInput{
+		ListenerArn: aws.String(listenerArn),
+		PageSize:    aws.Int32(elbPageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+	certificates = append(certificates, output.Certificates...)
+	for output.NextMarker != nil {
+		output, err = client.DescribeListenerCertificates(context.TODO(), &elasticloadbalancingv2.DescribeListenerCertificatesInput{
+			ListenerArn: aws.String(listenerArn),
+			Marker:      output.NextMarker,
+			PageSize:    aws.Int32(elbPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, output.Certificates...)
+	}
+	return certificates, nil
+}
+
+// showElbListeners shows a row for each rule. Rows keep the evaluation order among the same values of the sort column.
+func showElbListeners(outputs *elbListenersOutputs, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"LB", "LISTENER", "SSL POLICY", "CERTIFICATE", "PRIORITY", "CONDITION", "ACTION"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	var records [][]string
+	for _, lb := range outputs.loadBalancers {
+		listeners := append([]types.Listener{}, outputs.listeners[*lb.LoadBalancerArn]...)
+		sort.SliceStable(listeners, func(i, j int) bool { return aws.ToInt32(listeners[i].Port) < aws.ToInt32(listeners[j].Port) })
+		for _, listener := range listeners {
+			certificates := outputs.certificates[*listener.ListenerArn]
+			if certificates == nil {
+				certificates = listener.Certificates
+			}
+			var certificateNames []string
+			for _, certificate := range certificates {
+				name := formatCertificate(aws.ToString(certificate.CertificateArn))
+				if aws.ToBool(certificate.IsDefault) {
+					name += "(default)"
+				}
+				certificateNames = append(certificateNames, name)
+			}
+			rules := sortElbRules(outputs.rules[*listener.ListenerArn])
+			if len(rules) == 0 {
+				// Network and Gateway Load Balancers have only the default actions
+				rules = []types.Rule{{Actions: listener.DefaultActions, IsDefault: true, Priority: aws.String("default")}}
+			}
+			for _, rule := range rules {
+				var conditions []string
+				for _, condition := range rule.Conditions {
+					conditions = append(conditions, formatRuleCondition(condition))
+				}
+				records = append(records, []string{
+					*lb.LoadBalancerName,
+					formatListener(listener),
+					aws.ToString(listener.SslPolicy),
+					joinValues(certificateNames),
+					aws.ToString(rule.Priority),
+					strings.Join(conditions, " AND "),
+					formatRuleActions(rule.Actions),
+				})
+			}
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// sortElbRules returns the rules in evaluation order. The priority of the default rule is "default".
+func sortElbRules(rules []types.Rule) []types.Rule {
+	sorted := append([]types.Rule{}, rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].IsDefault != sorted[j].IsDefault {
+			return !sorted[i].IsDefault
+		}
+		pi, _ := strconv.Atoi(aws.ToString(sorted[i].Priority))
+		pj, _ := strconv.Atoi(aws.ToString(sorted[j].Priority))
+		return pi < pj
+	})
+	return sorted
+}
+
+// formatCertificate shows the resource part of the ARN such as certificate/id for ACM and server-certificate/name for IAM.
+func formatCertificate(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
+
+// formatRuleCondition formats a condition as "field=value1,value2". The values of a condition are ORed.
+func formatRuleCondition(condition types.RuleCondition) string {
+	field := aws.ToString(condition.Field)
+	values := condition.Values
+	switch {
+	case condition.HostHeaderConfig != nil:
+		values = condition.HostHeaderConfig.Values
+	case condition.PathPatternConfig != nil:
+		values = condition.PathPatternConfig.Values
+	case condition.HttpRequestMethodConfig != nil:
+		values = condition.HttpRequestMethodConfig.Values
+	case condition.SourceIpConfig != nil:
+		values = condition.SourceIpConfig.Values
+	case condition.HttpHeaderConfig != nil:
+		field = fmt.Sprintf("%s:%s", field, aws.ToString(condition.HttpHeaderConfig.HttpHeaderName))
+		values = condition.HttpHeaderConfig.Values
+	case condition.QueryStringConfig != nil:
+		values = nil
+		for _, pair := range condition.QueryStringConfig.Values {
+			if pair.Key != nil {
+				values = append(values, fmt.Sprintf("%s=%s", *pair.Key, aws.ToString(pair.Value)))
+			} else {
+				values = append(values, aws.ToString(pair.Value))
+			}
+		}
+	}
+	return fmt.Sprintf("%s=%s", field, strings.Join(values, ","))
+}
+
+// formatRuleActions formats the actions in the order they are performed, joined by " -> ".
+func formatRuleActions(actions []types.Action) string {
+	sorted := append([]types.Action{}, actions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return aws.ToInt32(sorted[i].Order) < aws.ToInt32(sorted[j].Order) })
+	var formatted []string
+	for _, action := range sorted {
+		formatted = append(formatted, formatRuleAction(action))
+	}
+	return strings.Join(formatted, " -> ")
+}
+
+func formatRuleAction(action types.Action) string {
+	switch action.Type {
+	case types.ActionTypeEnumForward:
+		if action.ForwardConfig != nil && len(action.ForwardConfig.TargetGroups) > 1 {
+			var targetGroups []string
+			for _, tuple := range action.ForwardConfig.TargetGroups {
+				targetGroups = append(targetGroups, fmt.Sprintf("%s(%d)", getTargetGroupName(aws.ToString(tuple.TargetGroupArn)), aws.ToInt32(tuple.Weight)))
+			}
+			return "forward:" + strings.Join(targetGroups, ",")
+		}
+		targetGroups := getActionTargetGroups([]types.Action{action})
+		if len(targetGroups) == 0 {
+			return "forward"
+		}
+		return "forward:" + getTargetGroupName(targetGroups[0])
+	case types.ActionTypeEnumRedirect:
+		config := action.RedirectConfig
+		if config == nil {
+			return "redirect"
+		}
+		// Omitted components keep the values of the request
+		return fmt.Sprintf("redirect:%s %s://%s:%s%s?%s",
+			strings.TrimPrefix(string(config.StatusCode), "HTTP_"),
+			stringOr(config.Protocol, "#{protocol}"),
+			stringOr(config.Host, "#{host}"),
+			stringOr(config.Port, "#{port}"),
+			stringOr(config.Path, "/#{path}"),
+			stringOr(config.Query, "#{query}"),
+		)
+	case types.ActionTypeEnumFixedResponse:
+		config := action.FixedResponseConfig
+		if config == nil {
+			return "fixed-response"
+		}
+		return strings.TrimSpace(fmt.Sprintf("fixed-response:%s %s", aws.ToString(config.StatusCode), aws.ToString(config.ContentType)))
+	}
+	return string(action.Type)
+}
+
+func stringOr(s *string, defaultValue string) string {
+	if s == nil {
+		return defaultValue
+	}
+	return *s
+}
@@ -0,0 +1,103 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+func Test_showElbListeners(t *testing.T) {
+	type args struct {
+		outputs      *elbListenersOutputs
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs: &elbListenersOutputs{
+					elbListenerOutputs: testElbListenerOutputs,
+					certificates: map[string][]types.Certificate{
+						testHttpsListener: {
+							{
+								CertificateArn: aws.String("arn:aws:acm:ap-northeast-1:111111111111:certificate/11111111-1111-1111-1111-111111111111"),
+								IsDefault:      aws.Bool(true),
+							},
+							{
+								CertificateArn: aws.String("arn:aws:iam::111111111111:server-certificate/legacy"),
+								IsDefault:      aws.Bool(false),
+							},
+						},
+					},
+				},
+				sortPosition: 1,
+			},
+			want: `+--------+-----------+---------------------------+-------------------------------------------------------------------------------------+----------+--------------------------------+--------------------------------------+
+|   LB   | LISTENER  |        SSL POLICY         |                                     CERTIFICATE                                     | PRIORITY |           CONDITION            |                ACTION                |
++--------+-----------+---------------------------+-------------------------------------------------------------------------------------+----------+--------------------------------+--------------------------------------+
+| web-lb | HTTP:80   |                           |                                                                                     | default  |                                | redirect:301                         |
+|        |           |                           |                                                                                     |          |                                | HTTPS://#{host}:443/#{path}?#{query} |
+| web-lb | HTTPS:443 | ELBSecurityPolicy-2016-08 | certificate/11111111-1111-1111-1111-111111111111(default),server-certificate/legacy |        5 | host-header=old.example.com    | fixed-response:503 text/plain        |
+|        |           |                           |                                                                                     |          | AND http-header:X-Debug=1      |                                      |
+| web-lb | HTTPS:443 | ELBSecurityPolicy-2016-08 | certificate/11111111-1111-1111-1111-111111111111(default),server-certificate/legacy |       10 | path-pattern=/api/*            | forward:api-tg(90),lambda-tg(10)     |
+| web-lb | HTTPS:443 | ELBSecurityPolicy-2016-08 | certificate/11111111-1111-1111-1111-111111111111(default),server-certificate/legacy | default  |                                | forward:web-tg                       |
++--------+-----------+---------------------------+-------------------------------------------------------------------------------------+----------+--------------------------------+--------------------------------------+
+`,
+		},
+		{
+			name: "network load balancer",
+			args: args{
+				outputs: &elbListenersOutputs{
+					elbListenerOutputs: &elbListenerOutputs{
+						loadBalancers: []types.LoadBalancer{
+							{
+								LoadBalancerArn:  aws.String("arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:loadbalancer/net/db-nlb/2222222222222222"),
+								LoadBalancerName: aws.String("db-nlb"),
+								Type:             "network",
+							},
+						},
+						listeners: map[string][]types.Listener{
+							"arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:loadbalancer/net/db-nlb/2222222222222222": {
+								{
+									DefaultActions: []types.Action{
+										{
+											TargetGroupArn: aws.String("arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:targetgroup/db-tg/4444444444444444"),
+											Type:           "forward",
+										},
+									},
+									ListenerArn: aws.String("arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:listener/net/db-nlb/2222222222222222/3333333333333333"),
+									Port:        aws.Int32(5432),
+									Protocol:    "TCP",
+								},
+							},
+						},
+					},
+				},
+				sortPosition: 1,
+			},
+			want: `+--------+----------+------------+-------------+----------+-----------+---------------+
+|   LB   | LISTENER | SSL POLICY | CERTIFICATE | PRIORITY | CONDITION |    ACTION     |
++--------+----------+------------+-------------+----------+-----------+---------------+
+| db-nlb | TCP:5432 |            |             | default  |           | forward:db-tg |
++--------+----------+------------+-------------+----------+-----------+---------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showElbListeners(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
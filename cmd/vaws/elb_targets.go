@@ -0,0 +1,298 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	classicTypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// elbTargetOutputs holds the target groups reached from the listeners and the health of their targets.
+type elbTargetOutputs struct {
+	*elbListenerOutputs
+	// target group ARN -> target health
+	targetHealth         map[string][]types.TargetHealthDescription
+	classicLoadBalancers []classicTypes.LoadBalancerDescription
+	// Classic Load Balancer name -> health of the registered instances
+	instanceHealth map[string][]classicTypes.InstanceState
+	instances      []*ec2.DescribeInstancesOutput
+}
+
+// elbTargetsCmd represents the elb targets command
+var elbTargetsCmd = &cobra.Command{
+	Use:   "targets [lb]",
+	Short: "Show the targets of load balancers and their health.",
+	Long: `Show the targets of load balancers and their health.
+The target groups are those that the listeners and their rules forward to.
+Classic Load Balancers show their registered instances without a target group.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		outputs, err := getElbTargetOutputs(newAwsConfig(), name)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showElbTargets(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	elbCmd.AddCommand(elbTargetsCmd)
+}
+
+func getElbTargetOutputs(cfg aws.Config, name string) (*elbTargetOutputs, error) {
+	listenerOutputs, err := getElbListenerOutputs(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	outputs := &elbTargetOutputs{
+		elbListenerOutputs: listenerOutputs,
+		targetHealth:       map[string][]types.TargetHealthDescription{},
+	}
+	client := elasticloadbalancingv2.NewFromConfig(cfg)
+	for _, lb := range outputs.loadBalancers {
+		for _, targetGroupArn := range outputs.getElbTargetGroups(*lb.LoadBalancerArn) {
+			// The DescribeTargetHealth API has no pagination
+			output, err := client.DescribeTargetHealth(context.TODO(), &elasticloadbalancingv2.DescribeTargetHealthInput{
+				TargetGroupArn: aws.String(targetGroupArn),
+			})
+			if err != nil {
+				return nil, err
+			}
+			outputs.targetHealth[targetGroupArn] = output.TargetHealthDescriptions
+		}
+	}
+	classicLoadBalancers, err := getClassicElb(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.instanceHealth = map[string][]classicTypes.InstanceState{}
+	classicClient := elasticloadbalancing.NewFromConfig(cfg)
+	for _, lb := range classicLoadBalancers {
+		if name != "" && aws.ToString(lb.LoadBalancerName) != name {
+			continue
+		}
+		outputs.classicLoadBalancers = append(outputs.classicLoadBalancers, lb)
+		// The DescribeInstanceHealth API has no pagination
+		output, err := classicClient.DescribeInstanceHealth(context.TODO(), &elasticloadbalancing.DescribeInstanceHealthInput{
+			LoadBalancerName: lb.LoadBalancerName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs.instanceHealth[*lb.LoadBalancerName] = output.InstanceStates
+	}
+	if len(outputs.loadBalancers) == 0 && len(outputs.classicLoadBalancers) == 0 && name != "" {
+		return nil, fmt.Errorf("no load balancer named %s", name)
+	}
+	outputs.instances, err = getEc2Instances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// getElbTargetGroups returns the target groups that the listeners of the load balancer forward to, in the order they are found.
+func (outputs *elbListenerOutputs) getElbTargetGroups(loadBalancerArn string) []string {
+	var targetGroups []string
+	seen := map[string]bool{}
+	for _, listener := range outputs.listeners[loadBalancerArn] {
+		for _, targetGroupArn := range outputs.getListenerTargetGroups(listener) {
+			if !seen[targetGroupArn] {
+				seen[targetGroupArn] = true
+				targetGroups = append(targetGroups, targetGroupArn)
+			}
+		}
+	}
+	return targetGroups
+}
+
+// getListenerTargetGroups returns the target groups that the default actions and the rules of the listener forward to.
+func (outputs *elbListenerOutputs) getListenerTargetGroups(listener types.Listener) []string {
+	var actions []types.Action
+	actions = append(actions, listener.DefaultActions...)
+	for _, rule := range outputs.rules[*listener.ListenerArn] {
+		actions = append(actions, rule.Actions...)
+	}
+	var targetGroups []string
+	seen := map[string]bool{}
+	for _, targetGroupArn := range getActionTargetGroups(actions) {
+		if !seen[targetGroupArn] {
+			seen[targetGroupArn] = true
+			targetGroups = append(targetGroups, targetGroupArn)
+		}
+	}
+	return targetGroups
+}
+
+// getActionTargetGroups returns the target groups of forward actions, including weighted ones.
+// A target group can appear more than once because forward actions may set both TargetGroupArn and ForwardConfig.
+func getActionTargetGroups(actions []types.Action) []string {
+	var targetGroups []string
+	for _, action := range actions {
+		if action.Type != types.ActionTypeEnumForward {
+			continue
+		}
+		if action.TargetGroupArn != nil {
+			targetGroups = append(targetGroups, *action.TargetGroupArn)
+		}
+		if action.ForwardConfig != nil {
+			for _, tuple := range action.ForwardConfig.TargetGroups {
+				if tuple.TargetGroupArn != nil {
+					targetGroups = append(targetGroups, *tuple.TargetGroupArn)
+				}
+			}
+		}
+	}
+	return targetGroups
+}
+
+func showElbTargets(outputs *elbTargetOutputs, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"LB", "LISTENER", "TARGET GROUP", "TARGET", "PORT", "AZ", "STATE", "REASON"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	instanceNames := getEc2InstanceNames(outputs.instances)
+	instanceZones := getEc2InstanceZones(outputs.instances)
+	var records [][]string
+	for _, lb := range outputs.loadBalancers {
+		// target group ARN -> listeners that forward to it
+		listeners := map[string][]string{}
+		for _, listener := range outputs.listeners[*lb.LoadBalancerArn] {
+			for _, targetGroupArn := range outputs.getListenerTargetGroups(listener) {
+				listeners[targetGroupArn] = append(listeners[targetGroupArn], formatListener(listener))
+			}
+		}
+		for _, targetGroupArn := range outputs.getElbTargetGroups(*lb.LoadBalancerArn) {
+			for _, health := range outputs.targetHealth[targetGroupArn] {
+				target, port, az := "", "", ""
+				if health.Target != nil {
+					target = formatElbTarget(aws.ToString(health.Target.Id), instanceNames)
+					if health.Target.Port != nil {
+						port = strconv.Itoa(int(*health.Target.Port))
+					}
+					az = aws.ToString(health.Target.AvailabilityZone)
+					// DescribeTargetHealth returns the AZ only for IP targets
+					if az == "" && strings.HasPrefix(aws.ToString(health.Target.Id), "i-") {
+						az = instanceZones[*health.Target.Id]
+					}
+				}
+				state, reason := "", ""
+				if health.TargetHealth != nil {
+					state = string(health.TargetHealth.State)
+					reason = string(health.TargetHealth.Reason)
+				}
+				records = append(records, []string{
+					*lb.LoadBalancerName,
+					joinValues(listeners[targetGroupArn]),
+					getTargetGroupName(targetGroupArn),
+					target,
+					port,
+					az,
+					state,
+					reason,
+				})
+			}
+		}
+	}
+	for _, lb := range outputs.classicLoadBalancers {
+		var listeners, ports []string
+		seen := map[int32]bool{}
+		for _, description := range lb.ListenerDescriptions {
+			if description.Listener == nil {
+				continue
+			}
+			listeners = append(listeners, formatClassicListener(*description.Listener))
+			if !seen[description.Listener.InstancePort] {
+				seen[description.Listener.InstancePort] = true
+				ports = append(ports, strconv.Itoa(int(description.Listener.InstancePort)))
+			}
+		}
+		for _, state := range outputs.instanceHealth[*lb.LoadBalancerName] {
+			instanceId := aws.ToString(state.InstanceId)
+			records = append(records, []string{
+				*lb.LoadBalancerName,
+				joinValues(listeners),
+				"",
+				formatElbTarget(instanceId, instanceNames),
+				joinValues(ports),
+				instanceZones[instanceId],
+				aws.ToString(state.State),
+				aws.ToString(state.ReasonCode),
+			})
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// formatElbTarget shows an instance target by its Name tag and a Lambda target by its function name.
+// IP targets are shown as they are.
+func formatElbTarget(id string, instanceNames map[string]string) string {
+	switch {
+	case strings.HasPrefix(id, "i-"):
+		return formatNameId(instanceNames[id], id)
+	case strings.HasPrefix(id, "arn:") && strings.Contains(id, ":function:"):
+		return "lambda:" + id[strings.Index(id, ":function:")+len(":function:"):]
+	case strings.HasPrefix(id, "arn:") && strings.Contains(id, ":loadbalancer/"):
+		parts := strings.Split(id, "/")
+		if len(parts) >= 3 {
+			return "alb:" + parts[len(parts)-2]
+		}
+	}
+	return id
+}
+
+// getEc2InstanceZones returns the availability zone of each instance ID.
+func getEc2InstanceZones(outputs []*ec2.DescribeInstancesOutput) map[string]string {
+	zones := map[string]string{}
+	for _, o := range outputs {
+		for _, r := range o.Reservations {
+			for _, instance := range r.Instances {
+				if instance.Placement != nil {
+					zones[*instance.InstanceId] = aws.ToString(instance.Placement.AvailabilityZone)
+				}
+			}
+		}
+	}
+	return zones
+}
@@ -0,0 +1,350 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	classicTypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+const (
+	testWebLbArn      = "arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:loadbalancer/app/web-lb/1111111111111111"
+	testHttpListener  = "arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:listener/app/web-lb/1111111111111111/1111111111111111"
+	testHttpsListener = "arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:listener/app/web-lb/1111111111111111/2222222222222222"
+	testWebTgArn      = "arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:targetgroup/web-tg/1111111111111111"
+	testApiTgArn      = "arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:targetgroup/api-tg/2222222222222222"
+	testLambdaTgArn   = "arn:aws:elasticloadbalancing:ap-northeast-1:111111111111:targetgroup/lambda-tg/3333333333333333"
+)
+
+var testElbListenerOutputs = &elbListenerOutputs{
+	loadBalancers: []types.LoadBalancer{
+		{
+			LoadBalancerArn:  aws.String(testWebLbArn),
+			LoadBalancerName: aws.String("web-lb"),
+			Type:             "application",
+		},
+	},
+	listeners: map[string][]types.Listener{
+		testWebLbArn: {
+			{
+				DefaultActions: []types.Action{
+					{
+						RedirectConfig: &types.RedirectActionConfig{
+							Port:       aws.String("443"),
+							Protocol:   aws.String("HTTPS"),
+							StatusCode: "HTTP_301",
+						},
+						Type: "redirect",
+					},
+				},
+				ListenerArn: aws.String(testHttpListener),
+				Port:        aws.Int32(80),
+				Protocol:    "HTTP",
+			},
+			{
+				Certificates: []types.Certificate{
+					{
+						CertificateArn: aws.String("arn:aws:acm:ap-northeast-1:111111111111:certificate/11111111-1111-1111-1111-111111111111"),
+					},
+				},
+				DefaultActions: []types.Action{
+					{
+						TargetGroupArn: aws.String(testWebTgArn),
+						Type:           "forward",
+					},
+				},
+				ListenerArn: aws.String(testHttpsListener),
+				Port:        aws.Int32(443),
+				Protocol:    "HTTPS",
+				SslPolicy:   aws.String("ELBSecurityPolicy-2016-08"),
+			},
+		},
+	},
+	rules: map[string][]types.Rule{
+		testHttpListener: {
+			{
+				Actions: []types.Action{
+					{
+						RedirectConfig: &types.RedirectActionConfig{
+							Port:       aws.String("443"),
+							Protocol:   aws.String("HTTPS"),
+							StatusCode: "HTTP_301",
+						},
+						Type: "redirect",
+					},
+				},
+				IsDefault: true,
+				Priority:  aws.String("default"),
+			},
+		},
+		testHttpsListener: {
+			{
+				Actions: []types.Action{
+					{
+						ForwardConfig: &types.ForwardActionConfig{
+							TargetGroups: []types.TargetGroupTuple{
+								{
+									TargetGroupArn: aws.String(testApiTgArn),
+									Weight:         aws.Int32(90),
+								},
+								{
+									TargetGroupArn: aws.String(testLambdaTgArn),
+									Weight:         aws.Int32(10),
+								},
+							},
+						},
+						Type: "forward",
+					},
+				},
+				Conditions: []types.RuleCondition{
+					{
+						Field: aws.String("path-pattern"),
+						PathPatternConfig: &types.PathPatternConditionConfig{
+							Values: []string{"/api/*"},
+						},
+					},
+				},
+				Priority: aws.String("10"),
+			},
+			{
+				Actions: []types.Action{
+					{
+						FixedResponseConfig: &types.FixedResponseActionConfig{
+							ContentType: aws.String("text/plain"),
+							MessageBody: aws.String("maintenance"),
+							StatusCode:  aws.String("503"),
+						},
+						Type: "fixed-response",
+					},
+				},
+				Conditions: []types.RuleCondition{
+					{
+						Field: aws.String("host-header"),
+						HostHeaderConfig: &types.HostHeaderConditionConfig{
+							Values: []string{"old.example.com"},
+						},
+					},
+					{
+						Field: aws.String("http-header"),
+						HttpHeaderConfig: &types.HttpHeaderConditionConfig{
+							HttpHeaderName: aws.String("X-Debug"),
+							Values:         []string{"1"},
+						},
+					},
+				},
+				Priority: aws.String("5"),
+			},
+			{
+				Actions: []types.Action{
+					{
+						TargetGroupArn: aws.String(testWebTgArn),
+						Type:           "forward",
+					},
+				},
+				IsDefault: true,
+				Priority:  aws.String("default"),
+			},
+		},
+	},
+}
+
+func Test_showElbTargets(t *testing.T) {
+	type args struct {
+		outputs      *elbTargetOutputs
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs: &elbTargetOutputs{
+					elbListenerOutputs: testElbListenerOutputs,
+					targetHealth: map[string][]types.TargetHealthDescription{
+						testWebTgArn: {
+							{
+								Target: &types.TargetDescription{
+									Id:   aws.String("i-11111111"),
+									Port: aws.Int32(80),
+								},
+								TargetHealth: &types.TargetHealth{
+									State: "healthy",
+								},
+							},
+							{
+								Target: &types.TargetDescription{
+									Id:   aws.String("i-22222222"),
+									Port: aws.Int32(80),
+								},
+								TargetHealth: &types.TargetHealth{
+									Description: aws.String("Health checks failed with these codes: [502]"),
+									Reason:      "Target.ResponseCodeMismatch",
+									State:       "unhealthy",
+								},
+							},
+						},
+						testApiTgArn: {
+							{
+								Target: &types.TargetDescription{
+									AvailabilityZone: aws.String("ap-northeast-1a"),
+									Id:               aws.String("10.0.1.50"),
+									Port:             aws.Int32(8080),
+								},
+								TargetHealth: &types.TargetHealth{
+									Reason: "Elb.RegistrationInProgress",
+									State:  "initial",
+								},
+							},
+						},
+						testLambdaTgArn: {
+							{
+								Target: &types.TargetDescription{
+									Id: aws.String("arn:aws:lambda:ap-northeast-1:111111111111:function:api-canary"),
+								},
+								TargetHealth: &types.TargetHealth{
+									Reason: "Target.HealthCheckDisabled",
+									State:  "unavailable",
+								},
+							},
+						},
+					},
+					instances: []*ec2.DescribeInstancesOutput{
+						{
+							Reservations: []ec2types.Reservation{
+								{
+									Instances: []ec2types.Instance{
+										{
+											InstanceId: aws.String("i-11111111"),
+											Placement: &ec2types.Placement{
+												AvailabilityZone: aws.String("ap-northeast-1a"),
+											},
+											Tags: []ec2types.Tag{
+												{
+													Key:   aws.String("Name"),
+													Value: aws.String("web1"),
+												},
+											},
+										},
+										{
+											InstanceId: aws.String("i-22222222"),
+											Placement: &ec2types.Placement{
+												AvailabilityZone: aws.String("ap-northeast-1c"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				sortPosition: 1,
+			},
+			want: `+--------+-----------+--------------+-------------------+------+-----------------+-------------+-----------------------------+
+|   LB   | LISTENER  | TARGET GROUP |      TARGET       | PORT |       AZ        |    STATE    |           REASON            |
++--------+-----------+--------------+-------------------+------+-----------------+-------------+-----------------------------+
+| web-lb | HTTPS:443 | web-tg       | web1(i-11111111)  |   80 | ap-northeast-1a | healthy     |                             |
+| web-lb | HTTPS:443 | web-tg       | i-22222222        |   80 | ap-northeast-1c | unhealthy   | Target.ResponseCodeMismatch |
+| web-lb | HTTPS:443 | api-tg       | 10.0.1.50         | 8080 | ap-northeast-1a | initial     | Elb.RegistrationInProgress  |
+| web-lb | HTTPS:443 | lambda-tg    | lambda:api-canary |      |                 | unavailable | Target.HealthCheckDisabled  |
++--------+-----------+--------------+-------------------+------+-----------------+-------------+-----------------------------+
+`,
+		},
+		{
+			name: "classic",
+			args: args{
+				outputs: &elbTargetOutputs{
+					elbListenerOutputs: &elbListenerOutputs{},
+					classicLoadBalancers: []classicTypes.LoadBalancerDescription{
+						{
+							ListenerDescriptions: []classicTypes.ListenerDescription{
+								{
+									Listener: &classicTypes.Listener{
+										InstancePort:     80,
+										LoadBalancerPort: 80,
+										Protocol:         aws.String("HTTP"),
+									},
+								},
+								{
+									Listener: &classicTypes.Listener{
+										InstancePort:     80,
+										LoadBalancerPort: 443,
+										Protocol:         aws.String("HTTPS"),
+									},
+								},
+							},
+							LoadBalancerName: aws.String("legacy-clb"),
+						},
+					},
+					instanceHealth: map[string][]classicTypes.InstanceState{
+						"legacy-clb": {
+							{
+								InstanceId: aws.String("i-11111111"),
+								ReasonCode: aws.String("N/A"),
+								State:      aws.String("InService"),
+							},
+							{
+								InstanceId: aws.String("i-22222222"),
+								ReasonCode: aws.String("Instance"),
+								State:      aws.String("OutOfService"),
+							},
+						},
+					},
+					instances: []*ec2.DescribeInstancesOutput{
+						{
+							Reservations: []ec2types.Reservation{
+								{
+									Instances: []ec2types.Instance{
+										{
+											InstanceId: aws.String("i-11111111"),
+											Placement: &ec2types.Placement{
+												AvailabilityZone: aws.String("ap-northeast-1a"),
+											},
+											Tags: []ec2types.Tag{
+												{
+													Key:   aws.String("Name"),
+													Value: aws.String("web1"),
+												},
+											},
+										},
+										{
+											InstanceId: aws.String("i-22222222"),
+											Placement: &ec2types.Placement{
+												AvailabilityZone: aws.String("ap-northeast-1c"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				sortPosition: 1,
+			},
+			want: `+------------+-------------------+--------------+------------------+------+-----------------+--------------+----------+
+|     LB     |     LISTENER      | TARGET GROUP |      TARGET      | PORT |       AZ        |    STATE     |  REASON  |
++------------+-------------------+--------------+------------------+------+-----------------+--------------+----------+
+| legacy-clb | HTTP:80,HTTPS:443 |              | web1(i-11111111) |   80 | ap-northeast-1a | InService    | N/A      |
+| legacy-clb | HTTP:80,HTTPS:443 |              | i-22222222       |   80 | ap-northeast-1c | OutOfService | Instance |
++------------+-------------------+--------------+------------------+------+-----------------+--------------+----------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showElbTargets(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
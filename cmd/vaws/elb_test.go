@@ -3,6 +3,7 @@ package vaws
 import (
 	"bytes"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	classicTypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing/types"
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 	"github.com/olekukonko/tablewriter"
@@ -11,9 +12,10 @@ import (
 
 func Test_showElb(t *testing.T) {
 	type args struct {
-		output       *elasticloadbalancingv2.DescribeLoadBalancersOutput
-		table        *tablewriter.Table
-		sortPosition int
+		output               *elasticloadbalancingv2.DescribeLoadBalancersOutput
+		classicLoadBalancers []classicTypes.LoadBalancerDescription
+		table                *tablewriter.Table
+		sortPosition         int
 	}
 	tests := []struct {
 		name string
@@ -137,13 +139,57 @@ func Test_showElb(t *testing.T) {
 | test-lb02 | application | internet-facing | vpc-xxxxxxxx | subnet-1234567e3xxxxxxxx,subnet-1234567e3zzzzzzzz | sg-084d3a6xxxxxxxxx | ipv4    | test-lb02.ap-northeast-1.elb.amazonaws.com |
 | test-lb01 | application | internet-facing | vpc-zzzzzzzz | subnet-1234567e3xxxxxxxx,subnet-1234567e3zzzzzzzz | sg-084d3a6xxxxxxxxx | ipv4    | test-lb01.ap-northeast-1.elb.amazonaws.com |
 +-----------+-------------+-----------------+--------------+---------------------------------------------------+---------------------+---------+--------------------------------------------+
+`,
+		},
+		{
+			name: "classic",
+			args: args{
+				output: &elasticloadbalancingv2.DescribeLoadBalancersOutput{
+					LoadBalancers: []types.LoadBalancer{
+						{
+							AvailabilityZones: []types.AvailabilityZone{
+								{
+									SubnetId: aws.String("subnet-1234567e3xxxxxxxx"),
+								},
+							},
+							DNSName:          aws.String("test-lb01.ap-northeast-1.elb.amazonaws.com"),
+							IpAddressType:    "ipv4",
+							LoadBalancerName: aws.String("test-lb01"),
+							Scheme:           "internet-facing",
+							Type:             "network",
+							VpcId:            aws.String("vpc-xxxxxxxx"),
+						},
+					},
+				},
+				classicLoadBalancers: []classicTypes.LoadBalancerDescription{
+					{
+						DNSName:          aws.String("test-clb01-123456789.ap-northeast-1.elb.amazonaws.com"),
+						LoadBalancerName: aws.String("test-clb01"),
+						Scheme:           aws.String("internal"),
+						SecurityGroups:   []string{"sg-084d3a6xxxxxxxxx"},
+						Subnets:          []string{"subnet-1234567e3xxxxxxxx", "subnet-1234567e3zzzzzzzz"},
+						VPCId:            aws.String("vpc-xxxxxxxx"),
+					},
+				},
+				sortPosition: 1,
+			},
+			want: `+------------+---------+-----------------+--------------+---------------------------------------------------+---------------------+---------+-------------------------------------------------------+
+|     LB     |  TYPE   |     SCHEME      |     VPC      |                      SUBNET                       |   SECURITY GROUP    | IP TYPE |                       DNS NAME                        |
++------------+---------+-----------------+--------------+---------------------------------------------------+---------------------+---------+-------------------------------------------------------+
+| test-clb01 | classic | internal        | vpc-xxxxxxxx | subnet-1234567e3xxxxxxxx,subnet-1234567e3zzzzzzzz | sg-084d3a6xxxxxxxxx | ipv4    | test-clb01-123456789.ap-northeast-1.elb.amazonaws.com |
+| test-lb01  | network | internet-facing | vpc-xxxxxxxx | subnet-1234567e3xxxxxxxx                          | none                | ipv4    | test-lb01.ap-northeast-1.elb.amazonaws.com            |
++------------+---------+-----------------+--------------+---------------------------------------------------+---------------------+---------+-------------------------------------------------------+
 `,
 		},
 	}
 	for _, tt := range tests {
 		var buf bytes.Buffer
 		t.Run(tt.name, func(t *testing.T) {
-			showElb(tt.args.output, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			outputs := &elbOutputs{
+				loadBalancers:        tt.args.output,
+				classicLoadBalancers: tt.args.classicLoadBalancers,
+			}
+			showElb(outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
 			if buf.String() != tt.want {
 				t.Errorf("failed to test: %s\n", tt.name)
 				t.Errorf("\nwant:\n%s\n", tt.want)
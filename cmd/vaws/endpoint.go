@@ -0,0 +1,262 @@
+package vaws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const endpointMaxResult = 1000
+
+// endpointCheckServices are the services that usually cost NAT gateway data processing unless they have a VPC endpoint.
+var endpointCheckServices = []string{"s3", "dynamodb", "ecr.api", "ecr.dkr", "sts"}
+
+// endpointOutputs holds the responses of the APIs that the endpoint command combines.
+type endpointOutputs struct {
+	endpoints   []*ec2.DescribeVpcEndpointsOutput
+	vpcs        []*ec2.DescribeVpcsOutput
+	subnets     []*ec2.DescribeSubnetsOutput
+	routeTables []*ec2.DescribeRouteTablesOutput
+}
+
+// endpointCmd represents the endpoint command
+var endpointCmd = &cobra.Command{
+	Use:   "endpoint",
+	Short: "Show VPC endpoints",
+	Long:  `Show VPC endpoints`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		outputs, err := getEndpoints(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		checkFlg, err := cmd.Flags().GetBool("check")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if checkFlg {
+			showEndpointCheck(outputs, tablewriter.NewWriter(os.Stdout))
+			return
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showEndpoints(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(endpointCmd)
+	endpointCmd.Flags().BoolP("check", "c", false, "Show missing endpoints of "+strings.Join(endpointCheckServices, ", ")+" in VPCs routing through NAT")
+}
+
+func getEndpoints(cfg aws.Config) (*endpointOutputs, error) {
+	var err error
+	outputs := &endpointOutputs{}
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeVpcEndpoints API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeVpcEndpoints(context.TODO(), &ec2.DescribeVpcEndpointsInput{
+		MaxResults: aws.Int32(endpointMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs.endpoints = append(outputs.endpoints, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeVpcEndpoints(context.TODO(), &ec2.DescribeVpcEndpointsInput{
+			MaxResults: aws.Int32(endpointMaxResult),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs.endpoints = append(outputs.endpoints, output)
+	}
+	outputs.vpcs, err = getVpc(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.subnets, err = getSubnets(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.routeTables, err = getRouteTables(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+func showEndpoints(outputs *endpointOutputs, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"NAME", "ID", "SERVICE", "TYPE", "VPC", "SUBNET", "ROUTE TABLE", "ENI", "SECURITY GROUP", "PRIVATE DNS", "POLICY", "STATE"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	vpcNames := getVpcNames(outputs.vpcs)
+	subnetNames := getSubnetNames(outputs.subnets)
+	var records [][]string
+	for _, o := range outputs.endpoints {
+		for _, endpoint := range o.VpcEndpoints {
+			var subnets, securityGroups []string
+			for _, subnet := range endpoint.SubnetIds {
+				subnets = append(subnets, formatNameId(subnetNames[subnet], subnet))
+			}
+			for _, sg := range endpoint.Groups {
+				securityGroups = append(securityGroups, formatNameId(*sg.GroupName, *sg.GroupId))
+			}
+			privateDns := ""
+			if endpoint.PrivateDnsEnabled != nil {
+				privateDns = strconv.FormatBool(*endpoint.PrivateDnsEnabled)
+			}
+			records = append(records, []string{
+				getNameTag(endpoint.Tags),
+				*endpoint.VpcEndpointId,
+				*endpoint.ServiceName,
+				string(endpoint.VpcEndpointType),
+				formatNameId(vpcNames[*endpoint.VpcId], *endpoint.VpcId),
+				joinValues(subnets),
+				joinValues(endpoint.RouteTableIds),
+				joinValues(endpoint.NetworkInterfaceIds),
+				joinValues(securityGroups),
+				privateDns,
+				endpointPolicy(endpoint.PolicyDocument),
+				string(endpoint.State),
+			})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// showEndpointCheck shows the endpoints of endpointCheckServices in each VPC that routes to a NAT gateway or NAT instance.
+// Traffic to a service without an endpoint goes through the NAT.
+func showEndpointCheck(outputs *endpointOutputs, table *tablewriter.Table) {
+	vpcNames := getVpcNames(outputs.vpcs)
+	natVpcs := map[string]bool{}
+	for _, o := range outputs.routeTables {
+		for _, rt := range o.RouteTables {
+			for _, route := range rt.Routes {
+				if route.State == types.RouteStateActive && (route.NatGatewayId != nil || route.InstanceId != nil) {
+					natVpcs[*rt.VpcId] = true
+				}
+			}
+		}
+	}
+	// vpc -> service -> endpoint IDs
+	endpoints := map[string]map[string][]string{}
+	for _, o := range outputs.endpoints {
+		for _, endpoint := range o.VpcEndpoints {
+			for _, service := range endpointCheckServices {
+				if !strings.HasSuffix(*endpoint.ServiceName, "."+service) {
+					continue
+				}
+				if endpoints[*endpoint.VpcId] == nil {
+					endpoints[*endpoint.VpcId] = map[string][]string{}
+				}
+				endpoints[*endpoint.VpcId][service] = append(endpoints[*endpoint.VpcId][service], *endpoint.VpcEndpointId)
+			}
+		}
+	}
+	var vpcs []string
+	for vpc := range natVpcs {
+		vpcs = append(vpcs, vpc)
+	}
+	sort.Strings(vpcs)
+	table.SetHeader([]string{"VPC", "SERVICE", "ENDPOINT"})
+	var records [][]string
+	for _, vpc := range vpcs {
+		for _, service := range endpointCheckServices {
+			endpoint := "missing"
+			if ids := endpoints[vpc][service]; len(ids) > 0 {
+				endpoint = joinValues(ids)
+			}
+			records = append(records, []string{formatNameId(vpcNames[vpc], vpc), service, endpoint})
+		}
+	}
+	table.AppendBulk(records)
+	table.Render()
+}
+
+// endpointPolicy returns "full-access" when the policy allows everything to everyone, and "custom" otherwise.
+func endpointPolicy(document *string) string {
+	if document == nil || *document == "" {
+		return ""
+	}
+	var policy struct {
+		Statement []struct {
+			Effect    string
+			Principal interface{}
+			Action    interface{}
+			Resource  interface{}
+		}
+	}
+	if err := json.Unmarshal([]byte(*document), &policy); err != nil {
+		return "custom"
+	}
+	fullAccess := false
+	for _, statement := range policy.Statement {
+		if statement.Effect == "Deny" {
+			return "custom"
+		}
+		if statement.Effect == "Allow" && isWildcard(statement.Principal) && isWildcard(statement.Action) && isWildcard(statement.Resource) {
+			fullAccess = true
+		}
+	}
+	if fullAccess {
+		return "full-access"
+	}
+	return "custom"
+}
+
+// isWildcard reports whether a policy element is "*", ["*"] or {"AWS": "*"}.
+func isWildcard(element interface{}) bool {
+	switch v := element.(type) {
+	case string:
+		return v == "*"
+	case []interface{}:
+		for _, e := range v {
+			if isWildcard(e) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		for _, e := range v {
+			if isWildcard(e) {
+				return true
+			}
+		}
+	}
+	return false
+}
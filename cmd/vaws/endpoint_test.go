@@ -0,0 +1,184 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+var testEndpointOutputs = &endpointOutputs{
+	endpoints: []*ec2.DescribeVpcEndpointsOutput{
+		{
+			VpcEndpoints: []types.VpcEndpoint{
+				{
+					PolicyDocument:  aws.String(`{"Version":"2008-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"*","Resource":"*"}]}`),
+					RouteTableIds:   []string{"rtb-11111111"},
+					ServiceName:     aws.String("com.amazonaws.ap-northeast-1.s3"),
+					State:           "available",
+					VpcEndpointId:   aws.String("vpce-11111111"),
+					VpcEndpointType: "Gateway",
+					VpcId:           aws.String("vpc-12345678"),
+				},
+				{
+					Groups: []types.SecurityGroupIdentifier{
+						{
+							GroupId:   aws.String("sg-11111111"),
+							GroupName: aws.String("endpoint"),
+						},
+					},
+					NetworkInterfaceIds: []string{"eni-11111111", "eni-22222222"},
+					PolicyDocument:      aws.String(`{"Statement":[{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::111111111111:root"},"Action":"ecr:*","Resource":"*"}]}`),
+					PrivateDnsEnabled:   aws.Bool(true),
+					ServiceName:         aws.String("com.amazonaws.ap-northeast-1.ecr.api"),
+					State:               "available",
+					SubnetIds:           []string{"subnet-aaaaaaaa", "subnet-cccccccc"},
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("ecr-api"),
+						},
+					},
+					VpcEndpointId:   aws.String("vpce-22222222"),
+					VpcEndpointType: "Interface",
+					VpcId:           aws.String("vpc-12345678"),
+				},
+			},
+		},
+	},
+	vpcs: []*ec2.DescribeVpcsOutput{
+		{
+			Vpcs: []types.Vpc{
+				{
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("app"),
+						},
+					},
+					VpcId: aws.String("vpc-12345678"),
+				},
+			},
+		},
+	},
+	subnets: []*ec2.DescribeSubnetsOutput{
+		{
+			Subnets: []types.Subnet{
+				{
+					SubnetId: aws.String("subnet-aaaaaaaa"),
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("private-a"),
+						},
+					},
+				},
+				{
+					SubnetId: aws.String("subnet-cccccccc"),
+				},
+			},
+		},
+	},
+	routeTables: []*ec2.DescribeRouteTablesOutput{
+		{
+			RouteTables: []types.RouteTable{
+				{
+					RouteTableId: aws.String("rtb-11111111"),
+					Routes: []types.Route{
+						{
+							DestinationCidrBlock: aws.String("0.0.0.0/0"),
+							NatGatewayId:         aws.String("nat-12345678"),
+							State:                "active",
+						},
+					},
+					VpcId: aws.String("vpc-12345678"),
+				},
+				{
+					RouteTableId: aws.String("rtb-22222222"),
+					Routes: []types.Route{
+						{
+							DestinationCidrBlock: aws.String("0.0.0.0/0"),
+							GatewayId:            aws.String("igw-12345678"),
+							State:                "active",
+						},
+					},
+					VpcId: aws.String("vpc-87654321"),
+				},
+			},
+		},
+	},
+}
+
+func Test_showEndpoints(t *testing.T) {
+	type args struct {
+		outputs      *endpointOutputs
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs:      testEndpointOutputs,
+				sortPosition: 2,
+			},
+			want: `+---------+---------------+--------------------------------------+-----------+-------------------+--------------------------------------------+--------------+---------------------------+-----------------------+-------------+-------------+-----------+
+|  NAME   |      ID       |               SERVICE                |   TYPE    |        VPC        |                   SUBNET                   | ROUTE TABLE  |            ENI            |    SECURITY GROUP     | PRIVATE DNS |   POLICY    |   STATE   |
++---------+---------------+--------------------------------------+-----------+-------------------+--------------------------------------------+--------------+---------------------------+-----------------------+-------------+-------------+-----------+
+|         | vpce-11111111 | com.amazonaws.ap-northeast-1.s3      | Gateway   | app(vpc-12345678) |                                            | rtb-11111111 |                           |                       |             | full-access | available |
+| ecr-api | vpce-22222222 | com.amazonaws.ap-northeast-1.ecr.api | Interface | app(vpc-12345678) | private-a(subnet-aaaaaaaa),subnet-cccccccc |              | eni-11111111,eni-22222222 | endpoint(sg-11111111) | true        | custom      | available |
++---------+---------------+--------------------------------------+-----------+-------------------+--------------------------------------------+--------------+---------------------------+-----------------------+-------------+-------------+-----------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showEndpoints(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
+
+func Test_showEndpointCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs *endpointOutputs
+		want    string
+	}{
+		{
+			name:    "default",
+			outputs: testEndpointOutputs,
+			want: `+-------------------+----------+---------------+
+|        VPC        | SERVICE  |   ENDPOINT    |
++-------------------+----------+---------------+
+| app(vpc-12345678) | s3       | vpce-11111111 |
+| app(vpc-12345678) | dynamodb | missing       |
+| app(vpc-12345678) | ecr.api  | vpce-22222222 |
+| app(vpc-12345678) | ecr.dkr  | missing       |
+| app(vpc-12345678) | sts      | missing       |
++-------------------+----------+---------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showEndpointCheck(tt.outputs, tablewriter.NewWriter(&buf))
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
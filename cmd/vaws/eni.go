@@ -0,0 +1,204 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strings"
+)
+
+const eniMaxResult = 1000
+
+// eniOutputs holds the responses of the APIs that the eni command combines.
+type eniOutputs struct {
+	interfaces []*ec2.DescribeNetworkInterfacesOutput
+	instances  []*ec2.DescribeInstancesOutput
+	subnets    []*ec2.DescribeSubnetsOutput
+}
+
+// eniCmd represents the eni command
+var eniCmd = &cobra.Command{
+	Use:   "eni",
+	Short: "Show network interfaces",
+	Long:  `Show network interfaces`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		outputs, err := getEnis(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showEnis(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(eniCmd)
+}
+
+func getEnis(cfg aws.Config) (*eniOutputs, error) {
+	var err error
+	outputs := &eniOutputs{}
+	outputs.interfaces, err = getNetworkInterfaces(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.instances, err = getEc2Instances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.subnets, err = getSubnets(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+func getNetworkInterfaces(cfg aws.Config) ([]*ec2.DescribeNetworkInterfacesOutput, error) {
+	var outputs []*ec2.DescribeNetworkInterfacesOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeNetworkInterfaces API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeNetworkInterfaces(context.TODO(), &ec2.DescribeNetworkInterfacesInput{
+		MaxResults: aws.Int32(eniMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeNetworkInterfaces(context.TODO(), &ec2.DescribeNetworkInterfacesInput{
+			MaxResults: aws.Int32(eniMaxResult),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+func showEnis(outputs *eniOutputs, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"ID", "TYPE", "OWNER", "SUBNET", "PRIVATE IP", "PUBLIC IP", "SECURITY GROUP", "STATUS"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	instanceNames := getEc2InstanceNames(outputs.instances)
+	subnetNames := getSubnetNames(outputs.subnets)
+	var records [][]string
+	for _, o := range outputs.interfaces {
+		for _, eni := range o.NetworkInterfaces {
+			subnet := ""
+			if eni.SubnetId != nil {
+				subnet = formatNameId(subnetNames[*eni.SubnetId], *eni.SubnetId)
+			}
+			var securityGroups []string
+			for _, sg := range eni.Groups {
+				securityGroups = append(securityGroups, formatNameId(*sg.GroupName, *sg.GroupId))
+			}
+			records = append(records, []string{
+				*eni.NetworkInterfaceId,
+				string(eni.InterfaceType),
+				eniOwner(eni, instanceNames),
+				subnet,
+				joinValues(eniPrivateIps(eni)),
+				joinValues(eniPublicIps(eni)),
+				joinValues(securityGroups),
+				string(eni.Status),
+			})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// eniOwner guesses the resource that created the network interface from its attachment, type and description.
+func eniOwner(eni types.NetworkInterface, instanceNames map[string]string) string {
+	description := ""
+	if eni.Description != nil {
+		description = *eni.Description
+	}
+	requesterManaged := eni.RequesterManaged != nil && *eni.RequesterManaged
+	switch {
+	case eni.Attachment != nil && eni.Attachment.InstanceId != nil && !requesterManaged:
+		return "instance " + formatNameId(instanceNames[*eni.Attachment.InstanceId], *eni.Attachment.InstanceId)
+	case strings.HasPrefix(description, "Interface for NAT Gateway "):
+		return "NAT " + strings.TrimPrefix(description, "Interface for NAT Gateway ")
+	case strings.HasPrefix(description, "ELB "):
+		return "ELB " + strings.TrimPrefix(description, "ELB ")
+	case strings.HasPrefix(description, "AWS Lambda VPC ENI-"):
+		return "Lambda " + strings.TrimPrefix(description, "AWS Lambda VPC ENI-")
+	case description == "RDSNetworkInterface":
+		return "RDS"
+	case strings.HasPrefix(description, "Amazon EKS "):
+		return "EKS " + strings.TrimPrefix(description, "Amazon EKS ")
+	case strings.HasPrefix(description, "aws-K8S-"):
+		return "EKS " + description
+	case strings.HasPrefix(description, "VPC Endpoint Interface "):
+		return "VPC endpoint " + strings.TrimPrefix(description, "VPC Endpoint Interface ")
+	case description != "":
+		return description
+	case eni.RequesterId != nil:
+		return *eni.RequesterId
+	}
+	return ""
+}
+
+// eniPrivateIps returns the primary private IP address followed by the secondary ones.
+func eniPrivateIps(eni types.NetworkInterface) []string {
+	var ips []string
+	if eni.PrivateIpAddress != nil {
+		ips = append(ips, *eni.PrivateIpAddress)
+	}
+	for _, ip := range eni.PrivateIpAddresses {
+		if ip.PrivateIpAddress != nil && (eni.PrivateIpAddress == nil || *ip.PrivateIpAddress != *eni.PrivateIpAddress) {
+			ips = append(ips, *ip.PrivateIpAddress)
+		}
+	}
+	return ips
+}
+
+// eniPublicIps returns the public IP addresses associated with the private IP addresses.
+func eniPublicIps(eni types.NetworkInterface) []string {
+	var ips []string
+	for _, ip := range eni.PrivateIpAddresses {
+		if ip.Association != nil && ip.Association.PublicIp != nil {
+			ips = append(ips, *ip.Association.PublicIp)
+		}
+	}
+	if len(ips) == 0 && eni.Association != nil && eni.Association.PublicIp != nil {
+		ips = append(ips, *eni.Association.PublicIp)
+	}
+	return ips
+}
@@ -0,0 +1,196 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+var testEniOutputs = &eniOutputs{
+	interfaces: []*ec2.DescribeNetworkInterfacesOutput{
+		{
+			NetworkInterfaces: []types.NetworkInterface{
+				{
+					Attachment: &types.NetworkInterfaceAttachment{
+						InstanceId: aws.String("i-11111111"),
+					},
+					Groups: []types.GroupIdentifier{
+						{
+							GroupId:   aws.String("sg-11111111"),
+							GroupName: aws.String("web"),
+						},
+					},
+					InterfaceType:      "interface",
+					NetworkInterfaceId: aws.String("eni-11111111"),
+					PrivateIpAddress:   aws.String("10.0.0.10"),
+					PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+						{
+							Association: &types.NetworkInterfaceAssociation{
+								PublicIp: aws.String("203.0.113.10"),
+							},
+							Primary:          aws.Bool(true),
+							PrivateIpAddress: aws.String("10.0.0.10"),
+						},
+						{
+							Primary:          aws.Bool(false),
+							PrivateIpAddress: aws.String("10.0.0.11"),
+						},
+					},
+					Status:   "in-use",
+					SubnetId: aws.String("subnet-aaaaaaaa"),
+				},
+				{
+					Description:        aws.String("Interface for NAT Gateway nat-12345678"),
+					InterfaceType:      "nat_gateway",
+					NetworkInterfaceId: aws.String("eni-22222222"),
+					PrivateIpAddress:   aws.String("10.0.0.5"),
+					PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+						{
+							Association: &types.NetworkInterfaceAssociation{
+								PublicIp: aws.String("203.0.113.5"),
+							},
+							Primary:          aws.Bool(true),
+							PrivateIpAddress: aws.String("10.0.0.5"),
+						},
+					},
+					RequesterId:      aws.String("111111111111"),
+					RequesterManaged: aws.Bool(true),
+					Status:           "in-use",
+					SubnetId:         aws.String("subnet-aaaaaaaa"),
+				},
+				{
+					Description:        aws.String("ELB app/web/1234567890abcdef"),
+					InterfaceType:      "interface",
+					NetworkInterfaceId: aws.String("eni-33333333"),
+					PrivateIpAddress:   aws.String("10.0.1.20"),
+					RequesterId:        aws.String("amazon-elb"),
+					RequesterManaged:   aws.Bool(true),
+					Status:             "in-use",
+					SubnetId:           aws.String("subnet-bbbbbbbb"),
+				},
+				{
+					Description:        aws.String("AWS Lambda VPC ENI-worker-1a2b3c4d"),
+					InterfaceType:      "lambda",
+					NetworkInterfaceId: aws.String("eni-44444444"),
+					PrivateIpAddress:   aws.String("10.0.1.30"),
+					RequesterManaged:   aws.Bool(true),
+					Status:             "in-use",
+					SubnetId:           aws.String("subnet-bbbbbbbb"),
+				},
+				{
+					Description:        aws.String("RDSNetworkInterface"),
+					InterfaceType:      "interface",
+					NetworkInterfaceId: aws.String("eni-55555555"),
+					PrivateIpAddress:   aws.String("10.0.1.40"),
+					RequesterId:        aws.String("amazon-rds"),
+					RequesterManaged:   aws.Bool(true),
+					Status:             "in-use",
+					SubnetId:           aws.String("subnet-bbbbbbbb"),
+				},
+			},
+		},
+	},
+	instances: []*ec2.DescribeInstancesOutput{
+		{
+			Reservations: []types.Reservation{
+				{
+					Instances: []types.Instance{
+						{
+							InstanceId:       aws.String("i-11111111"),
+							PrivateIpAddress: aws.String("10.0.0.10"),
+							PublicIpAddress:  aws.String("203.0.113.10"),
+							State: &types.InstanceState{
+								Name: "running",
+							},
+							Tags: []types.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("web1"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	subnets: []*ec2.DescribeSubnetsOutput{
+		{
+			Subnets: []types.Subnet{
+				{
+					SubnetId: aws.String("subnet-aaaaaaaa"),
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("public-a"),
+						},
+					},
+				},
+				{
+					SubnetId: aws.String("subnet-bbbbbbbb"),
+				},
+			},
+		},
+	},
+}
+
+func Test_showEnis(t *testing.T) {
+	type args struct {
+		outputs      *eniOutputs
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs:      testEniOutputs,
+				sortPosition: 1,
+			},
+			want: `+--------------+-------------+------------------------------+---------------------------+---------------------+--------------+------------------+--------+
+|      ID      |    TYPE     |            OWNER             |          SUBNET           |     PRIVATE IP      |  PUBLIC IP   |  SECURITY GROUP  | STATUS |
++--------------+-------------+------------------------------+---------------------------+---------------------+--------------+------------------+--------+
+| eni-11111111 | interface   | instance web1(i-11111111)    | public-a(subnet-aaaaaaaa) | 10.0.0.10,10.0.0.11 | 203.0.113.10 | web(sg-11111111) | in-use |
+| eni-22222222 | nat_gateway | NAT nat-12345678             | public-a(subnet-aaaaaaaa) | 10.0.0.5            | 203.0.113.5  |                  | in-use |
+| eni-33333333 | interface   | ELB app/web/1234567890abcdef | subnet-bbbbbbbb           | 10.0.1.20           |              |                  | in-use |
+| eni-44444444 | lambda      | Lambda worker-1a2b3c4d       | subnet-bbbbbbbb           | 10.0.1.30           |              |                  | in-use |
+| eni-55555555 | interface   | RDS                          | subnet-bbbbbbbb           | 10.0.1.40           |              |                  | in-use |
++--------------+-------------+------------------------------+---------------------------+---------------------+--------------+------------------+--------+
+`,
+		},
+		{
+			name: "sort by owner",
+			args: args{
+				outputs:      testEniOutputs,
+				sortPosition: 3,
+			},
+			want: `+--------------+-------------+------------------------------+---------------------------+---------------------+--------------+------------------+--------+
+|      ID      |    TYPE     |            OWNER             |          SUBNET           |     PRIVATE IP      |  PUBLIC IP   |  SECURITY GROUP  | STATUS |
++--------------+-------------+------------------------------+---------------------------+---------------------+--------------+------------------+--------+
+| eni-33333333 | interface   | ELB app/web/1234567890abcdef | subnet-bbbbbbbb           | 10.0.1.20           |              |                  | in-use |
+| eni-44444444 | lambda      | Lambda worker-1a2b3c4d       | subnet-bbbbbbbb           | 10.0.1.30           |              |                  | in-use |
+| eni-22222222 | nat_gateway | NAT nat-12345678             | public-a(subnet-aaaaaaaa) | 10.0.0.5            | 203.0.113.5  |                  | in-use |
+| eni-55555555 | interface   | RDS                          | subnet-bbbbbbbb           | 10.0.1.40           |              |                  | in-use |
+| eni-11111111 | interface   | instance web1(i-11111111)    | public-a(subnet-aaaaaaaa) | 10.0.0.10,10.0.0.11 | 203.0.113.10 | web(sg-11111111) | in-use |
++--------------+-------------+------------------------------+---------------------------+---------------------+--------------+------------------+--------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showEnis(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
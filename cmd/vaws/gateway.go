@@ -0,0 +1,299 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+)
+
+const (
+	internetGatewayMaxResult           = 1000
+	natGatewayMaxResult                = 1000
+	egressOnlyInternetGatewayMaxResult = 255
+)
+
+// gatewayOutputs holds the responses of the APIs that the gateway command combines.
+type gatewayOutputs struct {
+	internetGateways           []*ec2.DescribeInternetGatewaysOutput
+	natGateways                []*ec2.DescribeNatGatewaysOutput
+	egressOnlyInternetGateways []*ec2.DescribeEgressOnlyInternetGatewaysOutput
+	subnets                    []*ec2.DescribeSubnetsOutput
+}
+
+// gatewayCmd represents the gateway command
+var gatewayCmd = &cobra.Command{
+	Use:   "gateway",
+	Short: "Show internet gateways, NAT gateways and egress-only internet gateways",
+	Long:  `Show internet gateways, NAT gateways and egress-only internet gateways`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		outputs, err := getGateways(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		natCoverageFlg, err := cmd.Flags().GetBool("nat-coverage")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if natCoverageFlg {
+			showNatCoverage(outputs, tablewriter.NewWriter(os.Stdout))
+			return
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showGateways(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(gatewayCmd)
+	gatewayCmd.Flags().BoolP("nat-coverage", "n", false, "Show available NAT gateways per AZ")
+}
+
+func getGateways(cfg aws.Config) (*gatewayOutputs, error) {
+	var err error
+	outputs := &gatewayOutputs{}
+	outputs.internetGateways, err = getInternetGateways(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.natGateways, err = getNatGateways(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.egressOnlyInternetGateways, err = getEgressOnlyInternetGateways(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.subnets, err = getSubnets(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+func getInternetGateways(cfg aws.Config) ([]*ec2.DescribeInternetGatewaysOutput, error) {
+	var outputs []*ec2.DescribeInternetGatewaysOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeInternetGateways API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeInternetGateways(context.TODO(), &ec2.DescribeInternetGatewaysInput{
+		MaxResults: aws.Int32(internetGatewayMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeInternetGateways(context.TODO(), &ec2.DescribeInternetGatewaysInput{
+			MaxResults: aws.Int32(internetGatewayMaxResult),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+func getNatGateways(cfg aws.Config) ([]*ec2.DescribeNatGatewaysOutput, error) {
+	var outputs []*ec2.DescribeNatGatewaysOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeNatGateways API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeNatGateways(context.TODO(), &ec2.DescribeNatGatewaysInput{
+		MaxResults: aws.Int32(natGatewayMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeNatGateways(context.TODO(), &ec2.DescribeNatGatewaysInput{
+			MaxResults: aws.Int32(natGatewayMaxResult),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+func getEgressOnlyInternetGateways(cfg aws.Config) ([]*ec2.DescribeEgressOnlyInternetGatewaysOutput, error) {
+	var outputs []*ec2.DescribeEgressOnlyInternetGatewaysOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeEgressOnlyInternetGateways API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeEgressOnlyInternetGateways(context.TODO(), &ec2.DescribeEgressOnlyInternetGatewaysInput{
+		MaxResults: aws.Int32(egressOnlyInternetGatewayMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeEgressOnlyInternetGateways(context.TODO(), &ec2.DescribeEgressOnlyInternetGatewaysInput{
+			MaxResults: aws.Int32(egressOnlyInternetGatewayMaxResult),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+func showGateways(outputs *gatewayOutputs, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"NAME", "TYPE", "ID", "VPC", "SUBNET", "AZ", "CONNECTIVITY", "STATE", "PUBLIC IP", "PRIVATE IP"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	var records [][]string
+	for _, o := range outputs.internetGateways {
+		for _, igw := range o.InternetGateways {
+			records = append(records, internetGatewayRecords("internet", getNameTag(igw.Tags), *igw.InternetGatewayId, igw.Attachments)...)
+		}
+	}
+	for _, o := range outputs.egressOnlyInternetGateways {
+		for _, eigw := range o.EgressOnlyInternetGateways {
+			records = append(records, internetGatewayRecords("egress-only", getNameTag(eigw.Tags), *eigw.EgressOnlyInternetGatewayId, eigw.Attachments)...)
+		}
+	}
+	subnetAzs := getSubnetAzs(outputs.subnets)
+	for _, o := range outputs.natGateways {
+		for _, nat := range o.NatGateways {
+			var publicIps, privateIps []string
+			for _, address := range nat.NatGatewayAddresses {
+				if address.PublicIp != nil {
+					publicIps = append(publicIps, *address.PublicIp)
+				}
+				if address.PrivateIp != nil {
+					privateIps = append(privateIps, *address.PrivateIp)
+				}
+			}
+			records = append(records, []string{
+				getNameTag(nat.Tags),
+				"nat",
+				*nat.NatGatewayId,
+				*nat.VpcId,
+				*nat.SubnetId,
+				subnetAzs[*nat.SubnetId],
+				string(nat.ConnectivityType),
+				string(nat.State),
+				joinValues(publicIps),
+				joinValues(privateIps),
+			})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// internetGatewayRecords returns a record per attached VPC, or a single record without VPC for a detached gateway.
+func internetGatewayRecords(gatewayType string, name string, id string, attachments []types.InternetGatewayAttachment) [][]string {
+	if len(attachments) == 0 {
+		return [][]string{{name, gatewayType, id, "", "", "", "", "detached", "", ""}}
+	}
+	var records [][]string
+	for _, attachment := range attachments {
+		records = append(records, []string{name, gatewayType, id, *attachment.VpcId, "", "", "", string(attachment.State), "", ""})
+	}
+	return records
+}
+
+// showNatCoverage shows the available NAT gateways of each AZ in the VPCs that use NAT gateways.
+// An AZ without its own NAT gateway loses internet access when the AZ of the NAT gateway fails.
+func showNatCoverage(outputs *gatewayOutputs, table *tablewriter.Table) {
+	subnetAzs := getSubnetAzs(outputs.subnets)
+	// vpc -> az -> NAT gateways
+	coverage := map[string]map[string][]string{}
+	for _, o := range outputs.subnets {
+		for _, subnet := range o.Subnets {
+			if coverage[*subnet.VpcId] == nil {
+				coverage[*subnet.VpcId] = map[string][]string{}
+			}
+			if _, ok := coverage[*subnet.VpcId][*subnet.AvailabilityZone]; !ok {
+				coverage[*subnet.VpcId][*subnet.AvailabilityZone] = nil
+			}
+		}
+	}
+	natVpcs := map[string]bool{}
+	for _, o := range outputs.natGateways {
+		for _, nat := range o.NatGateways {
+			if nat.State != types.NatGatewayStateAvailable || nat.ConnectivityType == types.ConnectivityTypePrivate {
+				continue
+			}
+			natVpcs[*nat.VpcId] = true
+			az := subnetAzs[*nat.SubnetId]
+			if coverage[*nat.VpcId] == nil {
+				coverage[*nat.VpcId] = map[string][]string{}
+			}
+			coverage[*nat.VpcId][az] = append(coverage[*nat.VpcId][az], *nat.NatGatewayId)
+		}
+	}
+	table.SetHeader([]string{"VPC", "AZ", "NAT GATEWAY"})
+	var records [][]string
+	for vpc := range natVpcs {
+		for az, nats := range coverage[vpc] {
+			natGateway := "none"
+			if len(nats) > 0 {
+				sort.Strings(nats)
+				natGateway = joinValues(nats)
+			}
+			records = append(records, []string{vpc, az, natGateway})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i][0] != records[j][0] {
+			return records[i][0] < records[j][0]
+		}
+		return records[i][1] < records[j][1]
+	})
+	table.AppendBulk(records)
+	table.Render()
+}
+
+// getSubnetAzs returns the AZ of each subnet ID.
+func getSubnetAzs(outputs []*ec2.DescribeSubnetsOutput) map[string]string {
+	azs := map[string]string{}
+	for _, o := range outputs {
+		for _, subnet := range o.Subnets {
+			azs[*subnet.SubnetId] = *subnet.AvailabilityZone
+		}
+	}
+	return azs
+}
@@ -0,0 +1,182 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+var testGatewayOutputs = &gatewayOutputs{
+	internetGateways: []*ec2.DescribeInternetGatewaysOutput{
+		{
+			InternetGateways: []types.InternetGateway{
+				{
+					Attachments: []types.InternetGatewayAttachment{
+						{
+							State: "available",
+							VpcId: aws.String("vpc-12345678"),
+						},
+					},
+					InternetGatewayId: aws.String("igw-12345678"),
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("main-igw"),
+						},
+					},
+				},
+				{
+					InternetGatewayId: aws.String("igw-87654321"),
+				},
+			},
+		},
+	},
+	natGateways: []*ec2.DescribeNatGatewaysOutput{
+		{
+			NatGateways: []types.NatGateway{
+				{
+					ConnectivityType: "public",
+					NatGatewayAddresses: []types.NatGatewayAddress{
+						{
+							PrivateIp: aws.String("10.0.0.10"),
+							PublicIp:  aws.String("13.112.0.1"),
+						},
+					},
+					NatGatewayId: aws.String("nat-11111111"),
+					State:        "available",
+					SubnetId:     aws.String("subnet-aaaaaaaa"),
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("nat-a"),
+						},
+					},
+					VpcId: aws.String("vpc-12345678"),
+				},
+				{
+					ConnectivityType: "public",
+					NatGatewayAddresses: []types.NatGatewayAddress{
+						{
+							PrivateIp: aws.String("10.0.1.10"),
+						},
+					},
+					NatGatewayId: aws.String("nat-22222222"),
+					State:        "failed",
+					SubnetId:     aws.String("subnet-cccccccc"),
+					VpcId:        aws.String("vpc-12345678"),
+				},
+			},
+		},
+	},
+	egressOnlyInternetGateways: []*ec2.DescribeEgressOnlyInternetGatewaysOutput{
+		{
+			EgressOnlyInternetGateways: []types.EgressOnlyInternetGateway{
+				{
+					Attachments: []types.InternetGatewayAttachment{
+						{
+							State: "attached",
+							VpcId: aws.String("vpc-12345678"),
+						},
+					},
+					EgressOnlyInternetGatewayId: aws.String("eigw-12345678"),
+				},
+			},
+		},
+	},
+	subnets: []*ec2.DescribeSubnetsOutput{
+		{
+			Subnets: []types.Subnet{
+				{
+					AvailabilityZone: aws.String("ap-northeast-1a"),
+					SubnetId:         aws.String("subnet-aaaaaaaa"),
+					VpcId:            aws.String("vpc-12345678"),
+				},
+				{
+					AvailabilityZone: aws.String("ap-northeast-1c"),
+					SubnetId:         aws.String("subnet-cccccccc"),
+					VpcId:            aws.String("vpc-12345678"),
+				},
+				{
+					AvailabilityZone: aws.String("ap-northeast-1a"),
+					SubnetId:         aws.String("subnet-bbbbbbbb"),
+					VpcId:            aws.String("vpc-87654321"),
+				},
+			},
+		},
+	},
+}
+
+func Test_showGateways(t *testing.T) {
+	type args struct {
+		outputs      *gatewayOutputs
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs:      testGatewayOutputs,
+				sortPosition: 3,
+			},
+			want: `+----------+-------------+---------------+--------------+-----------------+-----------------+--------------+-----------+------------+------------+
+|   NAME   |    TYPE     |      ID       |     VPC      |     SUBNET      |       AZ        | CONNECTIVITY |   STATE   | PUBLIC IP  | PRIVATE IP |
++----------+-------------+---------------+--------------+-----------------+-----------------+--------------+-----------+------------+------------+
+|          | egress-only | eigw-12345678 | vpc-12345678 |                 |                 |              | attached  |            |            |
+| main-igw | internet    | igw-12345678  | vpc-12345678 |                 |                 |              | available |            |            |
+|          | internet    | igw-87654321  |              |                 |                 |              | detached  |            |            |
+| nat-a    | nat         | nat-11111111  | vpc-12345678 | subnet-aaaaaaaa | ap-northeast-1a | public       | available | 13.112.0.1 | 10.0.0.10  |
+|          | nat         | nat-22222222  | vpc-12345678 | subnet-cccccccc | ap-northeast-1c | public       | failed    |            | 10.0.1.10  |
++----------+-------------+---------------+--------------+-----------------+-----------------+--------------+-----------+------------+------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showGateways(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
+
+func Test_showNatCoverage(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs *gatewayOutputs
+		want    string
+	}{
+		{
+			name:    "default",
+			outputs: testGatewayOutputs,
+			want: `+--------------+-----------------+--------------+
+|     VPC      |       AZ        | NAT GATEWAY  |
++--------------+-----------------+--------------+
+| vpc-12345678 | ap-northeast-1a | nat-11111111 |
+| vpc-12345678 | ap-northeast-1c | none         |
++--------------+-----------------+--------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showNatCoverage(tt.outputs, tablewriter.NewWriter(&buf))
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package vaws
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// inventoryCmd represents the inventory command
+var inventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Generate inventories of EC2 instances for other tools",
+	Long:  `Generate inventories of EC2 instances for other tools`,
+}
+
+func init() {
+	rootCmd.AddCommand(inventoryCmd)
+}
@@ -0,0 +1,166 @@
+package vaws
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"net"
+	"os"
+)
+
+// ipOutputs holds the resources that the ip command searches for an address.
+type ipOutputs struct {
+	interfaces    []*ec2.DescribeNetworkInterfacesOutput
+	addresses     *ec2.DescribeAddressesOutput
+	instances     []*ec2.DescribeInstancesOutput
+	loadBalancers *elasticloadbalancingv2.DescribeLoadBalancersOutput
+	dbInstances   *rds.DescribeDBInstancesOutput
+}
+
+// ipCmd represents the ip command
+var ipCmd = &cobra.Command{
+	Use:   "ip <address>",
+	Short: "Show the resources that own an IP address",
+	Long: `Show the resources that own an IP address.
+Network interfaces, Elastic IPs, EC2 instances and the DNS records of load balancers and RDS instances are searched.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		if net.ParseIP(args[0]) == nil {
+			fmt.Printf("%s is not an IP address\n", args[0])
+			os.Exit(1)
+		}
+		outputs, err := getIpOutputs(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		showIpOwners(args[0], outputs, net.LookupHost, tablewriter.NewWriter(os.Stdout))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(ipCmd)
+}
+
+func getIpOutputs(cfg aws.Config) (*ipOutputs, error) {
+	var err error
+	outputs := &ipOutputs{}
+	outputs.interfaces, err = getNetworkInterfaces(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.addresses, err = getElasticIps(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.instances, err = getEc2Instances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.loadBalancers, err = getElb(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.dbInstances, err = getRdsInstances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// showIpOwners shows every resource that has the address.
+// lookupHost resolves the DNS names of load balancers and RDS instances.
+func showIpOwners(address string, outputs *ipOutputs, lookupHost func(host string) ([]string, error), table *tablewriter.Table) {
+	table.SetHeader([]string{"SOURCE", "RESOURCE", "ADDRESS TYPE", "DETAIL"})
+	instanceNames := getEc2InstanceNames(outputs.instances)
+	var records [][]string
+	for _, o := range outputs.interfaces {
+		for _, eni := range o.NetworkInterfaces {
+			for _, ip := range eniPrivateIps(eni) {
+				if ip == address {
+					records = append(records, []string{"eni", *eni.NetworkInterfaceId, "private", eniOwner(eni, instanceNames)})
+				}
+			}
+			for _, ip := range eniPublicIps(eni) {
+				if ip == address {
+					records = append(records, []string{"eni", *eni.NetworkInterfaceId, "public", eniOwner(eni, instanceNames)})
+				}
+			}
+		}
+	}
+	if outputs.addresses != nil {
+		for _, eip := range outputs.addresses.Addresses {
+			detail := ""
+			if eip.InstanceId != nil {
+				detail = "instance " + formatNameId(instanceNames[*eip.InstanceId], *eip.InstanceId)
+			} else if eip.NetworkInterfaceId != nil {
+				detail = *eip.NetworkInterfaceId
+			}
+			if eip.PublicIp != nil && *eip.PublicIp == address {
+				records = append(records, []string{"eip", *eip.AllocationId, "public", detail})
+			}
+			if eip.PrivateIpAddress != nil && *eip.PrivateIpAddress == address {
+				records = append(records, []string{"eip", *eip.AllocationId, "private", detail})
+			}
+		}
+	}
+	for _, o := range outputs.instances {
+		for _, r := range o.Reservations {
+			for _, instance := range r.Instances {
+				resource := formatNameId(getNameTag(instance.Tags), *instance.InstanceId)
+				if instance.PrivateIpAddress != nil && *instance.PrivateIpAddress == address {
+					records = append(records, []string{"ec2", resource, "private", string(instance.State.Name)})
+				}
+				if instance.PublicIpAddress != nil && *instance.PublicIpAddress == address {
+					records = append(records, []string{"ec2", resource, "public", string(instance.State.Name)})
+				}
+			}
+		}
+	}
+	if outputs.loadBalancers != nil {
+		for _, lb := range outputs.loadBalancers.LoadBalancers {
+			if lb.DNSName != nil && resolvesTo(*lb.DNSName, address, lookupHost) {
+				records = append(records, []string{"elb", *lb.LoadBalancerName, "dns", *lb.DNSName})
+			}
+		}
+	}
+	if outputs.dbInstances != nil {
+		for _, db := range outputs.dbInstances.DBInstances {
+			if db.Endpoint != nil && db.Endpoint.Address != nil && resolvesTo(*db.Endpoint.Address, address, lookupHost) {
+				records = append(records, []string{"rds", *db.DBInstanceIdentifier, "dns", *db.Endpoint.Address})
+			}
+		}
+	}
+	table.AppendBulk(records)
+	table.Render()
+}
+
+// resolvesTo reports whether the host currently resolves to the address. Resolution errors are ignored.
+func resolvesTo(host string, address string, lookupHost func(host string) ([]string, error)) bool {
+	addresses, err := lookupHost(host)
+	if err != nil {
+		return false
+	}
+	for _, a := range addresses {
+		if a == address {
+			return true
+		}
+	}
+	return false
+}
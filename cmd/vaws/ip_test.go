@@ -0,0 +1,139 @@
+package vaws
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	elbTypes "github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	rdsTypes "github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+var testIpOutputs = &ipOutputs{
+	interfaces: testEniOutputs.interfaces,
+	addresses: &ec2.DescribeAddressesOutput{
+		Addresses: []types.Address{
+			{
+				AllocationId:       aws.String("eipalloc-11111111"),
+				InstanceId:         aws.String("i-11111111"),
+				NetworkInterfaceId: aws.String("eni-11111111"),
+				PrivateIpAddress:   aws.String("10.0.0.10"),
+				PublicIp:           aws.String("203.0.113.10"),
+			},
+		},
+	},
+	instances: testEniOutputs.instances,
+	loadBalancers: &elasticloadbalancingv2.DescribeLoadBalancersOutput{
+		LoadBalancers: []elbTypes.LoadBalancer{
+			{
+				DNSName:          aws.String("web-1234567890.ap-northeast-1.elb.amazonaws.com"),
+				LoadBalancerName: aws.String("web"),
+			},
+		},
+	},
+	dbInstances: &rds.DescribeDBInstancesOutput{
+		DBInstances: []rdsTypes.DBInstance{
+			{
+				DBInstanceIdentifier: aws.String("db1"),
+				Endpoint: &rdsTypes.Endpoint{
+					Address: aws.String("db1.abcdefghijkl.ap-northeast-1.rds.amazonaws.com"),
+				},
+			},
+		},
+	},
+}
+
+func testLookupHost(host string) ([]string, error) {
+	hosts := map[string][]string{
+		"web-1234567890.ap-northeast-1.elb.amazonaws.com":   {"10.0.1.20", "10.0.2.20"},
+		"db1.abcdefghijkl.ap-northeast-1.rds.amazonaws.com": {"10.0.1.40"},
+	}
+	if addresses, ok := hosts[host]; ok {
+		return addresses, nil
+	}
+	return nil, fmt.Errorf("no such host")
+}
+
+func Test_showIpOwners(t *testing.T) {
+	type args struct {
+		address string
+		outputs *ipOutputs
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "instance public ip",
+			args: args{
+				address: "203.0.113.10",
+				outputs: testIpOutputs,
+			},
+			want: `+--------+-------------------+--------------+---------------------------+
+| SOURCE |     RESOURCE      | ADDRESS TYPE |          DETAIL           |
++--------+-------------------+--------------+---------------------------+
+| eni    | eni-11111111      | public       | instance web1(i-11111111) |
+| eip    | eipalloc-11111111 | public       | instance web1(i-11111111) |
+| ec2    | web1(i-11111111)  | public       | running                   |
++--------+-------------------+--------------+---------------------------+
+`,
+		},
+		{
+			name: "load balancer private ip",
+			args: args{
+				address: "10.0.1.20",
+				outputs: testIpOutputs,
+			},
+			want: `+--------+--------------+--------------+-------------------------------------------------+
+| SOURCE |   RESOURCE   | ADDRESS TYPE |                     DETAIL                      |
++--------+--------------+--------------+-------------------------------------------------+
+| eni    | eni-33333333 | private      | ELB app/web/1234567890abcdef                    |
+| elb    | web          | dns          | web-1234567890.ap-northeast-1.elb.amazonaws.com |
++--------+--------------+--------------+-------------------------------------------------+
+`,
+		},
+		{
+			name: "rds private ip",
+			args: args{
+				address: "10.0.1.40",
+				outputs: testIpOutputs,
+			},
+			want: `+--------+--------------+--------------+---------------------------------------------------+
+| SOURCE |   RESOURCE   | ADDRESS TYPE |                      DETAIL                       |
++--------+--------------+--------------+---------------------------------------------------+
+| eni    | eni-55555555 | private      | RDS                                               |
+| rds    | db1          | dns          | db1.abcdefghijkl.ap-northeast-1.rds.amazonaws.com |
++--------+--------------+--------------+---------------------------------------------------+
+`,
+		},
+		{
+			name: "unknown ip",
+			args: args{
+				address: "192.0.2.1",
+				outputs: testIpOutputs,
+			},
+			want: `+--------+----------+--------------+--------+
+| SOURCE | RESOURCE | ADDRESS TYPE | DETAIL |
++--------+----------+--------------+--------+
++--------+----------+--------------+--------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showIpOwners(tt.args.address, tt.args.outputs, testLookupHost, tablewriter.NewWriter(&buf))
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
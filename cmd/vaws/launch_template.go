@@ -0,0 +1,185 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strconv"
+)
+
+const launchTemplateMaxResults = 200
+
+// launchTemplateOutputs holds the launch templates and their default versions.
+type launchTemplateOutputs struct {
+	templates []*ec2.DescribeLaunchTemplatesOutput
+	versions  []*ec2.DescribeLaunchTemplateVersionsOutput
+}
+
+// launchTemplateCmd represents the launch-template command
+var launchTemplateCmd = &cobra.Command{
+	Use:   "launch-template",
+	Short: "Show launch templates",
+	Long: `Show launch templates.
+AMI, TYPE, SECURITY GROUP and USER DATA are the values of the default version.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		outputs, err := getLaunchTemplateOutputs(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showLaunchTemplates(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(launchTemplateCmd)
+}
+
+func getLaunchTemplateOutputs(cfg aws.Config) (*launchTemplateOutputs, error) {
+	var err error
+	outputs := &launchTemplateOutputs{}
+	outputs.templates, err = getLaunchTemplates(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// $Default without a template name or ID returns the default versions of all templates
+	outputs.versions, err = getLaunchTemplateVersions(cfg, &ec2.DescribeLaunchTemplateVersionsInput{
+		Versions: []string{"$Default"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+func getLaunchTemplates(cfg aws.Config) ([]*ec2.DescribeLaunchTemplatesOutput, error) {
+	var outputs []*ec2.DescribeLaunchTemplatesOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeLaunchTemplates API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeLaunchTemplates(context.TODO(), &ec2.DescribeLaunchTemplatesInput{
+		MaxResults: aws.Int32(launchTemplateMaxResults),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeLaunchTemplates(context.TODO(), &ec2.DescribeLaunchTemplatesInput{
+			MaxResults: aws.Int32(launchTemplateMaxResults),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// getLaunchTemplateVersions returns all pages of the versions that the input selects.
+func getLaunchTemplateVersions(cfg aws.Config, input *ec2.DescribeLaunchTemplateVersionsInput) ([]*ec2.DescribeLaunchTemplateVersionsOutput, error) {
+	var outputs []*ec2.DescribeLaunchTemplateVersionsOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	input.MaxResults = aws.Int32(launchTemplateMaxResults)
+	// The DescribeLaunchTemplateVersions API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeLaunchTemplateVersions(context.TODO(), input)
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		input.NextToken = output.NextToken
+		output, err = client.DescribeLaunchTemplateVersions(context.TODO(), input)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+func showLaunchTemplates(outputs *launchTemplateOutputs, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"NAME", "ID", "DEFAULT", "LATEST", "AMI", "TYPE", "SECURITY GROUP", "USER DATA"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	// launch template ID -> default version data
+	defaults := map[string]*types.ResponseLaunchTemplateData{}
+	for _, o := range outputs.versions {
+		for _, version := range o.LaunchTemplateVersions {
+			defaults[aws.ToString(version.LaunchTemplateId)] = version.LaunchTemplateData
+		}
+	}
+	var records [][]string
+	for _, o := range outputs.templates {
+		for _, template := range o.LaunchTemplates {
+			record := []string{
+				aws.ToString(template.LaunchTemplateName),
+				*template.LaunchTemplateId,
+				strconv.FormatInt(aws.ToInt64(template.DefaultVersionNumber), 10),
+				strconv.FormatInt(aws.ToInt64(template.LatestVersionNumber), 10),
+				"",
+				"",
+				"",
+				"",
+			}
+			if data := defaults[*template.LaunchTemplateId]; data != nil {
+				record[4] = aws.ToString(data.ImageId)
+				record[5] = string(data.InstanceType)
+				record[6] = joinValues(getLaunchTemplateSecurityGroups(data))
+				if aws.ToString(data.UserData) != "" {
+					record[7] = "yes"
+				} else {
+					record[7] = "no"
+				}
+			}
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// getLaunchTemplateSecurityGroups returns the security groups of the template and its network interfaces.
+func getLaunchTemplateSecurityGroups(data *types.ResponseLaunchTemplateData) []string {
+	var groups []string
+	groups = append(groups, data.SecurityGroupIds...)
+	groups = append(groups, data.SecurityGroups...)
+	for _, eni := range data.NetworkInterfaces {
+		groups = append(groups, eni.Groups...)
+	}
+	return groups
+}
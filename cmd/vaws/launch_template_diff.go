@@ -0,0 +1,183 @@
+package vaws
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// launchTemplateDiffCmd represents the launch-template diff command
+var launchTemplateDiffCmd = &cobra.Command{
+	Use:   "diff <name> <version> <version>",
+	Short: "Show the fields that differ between two versions of a launch template",
+	Long: `Show the fields that differ between two versions of a launch template.
+Versions are numbers, $Latest or $Default. User data is compared by its SHA-256 hash.`,
+	Args: cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		outputs, err := getLaunchTemplateVersions(newAwsConfig(), &ec2.DescribeLaunchTemplateVersionsInput{
+			LaunchTemplateName: aws.String(args[0]),
+			Versions:           args[1:],
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		from, to, err := findLaunchTemplateVersions(outputs, args[1], args[2])
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showLaunchTemplateDiff(from, to, tablewriter.NewWriter(os.Stdout))
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	launchTemplateCmd.AddCommand(launchTemplateDiffCmd)
+}
+
+// findLaunchTemplateVersions returns the versions in the order of the arguments.
+func findLaunchTemplateVersions(outputs []*ec2.DescribeLaunchTemplateVersionsOutput, from string, to string) (types.LaunchTemplateVersion, types.LaunchTemplateVersion, error) {
+	var versions []types.LaunchTemplateVersion
+	for _, o := range outputs {
+		versions = append(versions, o.LaunchTemplateVersions...)
+	}
+	var found [2]types.LaunchTemplateVersion
+	for i, key := range []string{from, to} {
+		version, ok := findLaunchTemplateVersion(versions, key)
+		if !ok {
+			return found[0], found[1], fmt.Errorf("no version %s", key)
+		}
+		found[i] = version
+	}
+	return found[0], found[1], nil
+}
+
+// findLaunchTemplateVersion finds a version by its number, $Default or $Latest.
+func findLaunchTemplateVersion(versions []types.LaunchTemplateVersion, key string) (types.LaunchTemplateVersion, bool) {
+	var found types.LaunchTemplateVersion
+	ok := false
+	for _, version := range versions {
+		switch key {
+		case "$Default":
+			if aws.ToBool(version.DefaultVersion) {
+				return version, true
+			}
+		case "$Latest":
+			if !ok || aws.ToInt64(version.VersionNumber) > aws.ToInt64(found.VersionNumber) {
+				found = version
+				ok = true
+			}
+		default:
+			if key == strconv.FormatInt(aws.ToInt64(version.VersionNumber), 10) {
+				return version, true
+			}
+		}
+	}
+	return found, ok
+}
+
+// showLaunchTemplateDiff shows the flattened fields whose values differ between the versions.
+func showLaunchTemplateDiff(from types.LaunchTemplateVersion, to types.LaunchTemplateVersion, table *tablewriter.Table) error {
+	fromFields, err := flattenLaunchTemplateData(from.LaunchTemplateData)
+	if err != nil {
+		return err
+	}
+	toFields, err := flattenLaunchTemplateData(to.LaunchTemplateData)
+	if err != nil {
+		return err
+	}
+	table.SetHeader([]string{
+		"FIELD",
+		"VERSION " + strconv.FormatInt(aws.ToInt64(from.VersionNumber), 10),
+		"VERSION " + strconv.FormatInt(aws.ToInt64(to.VersionNumber), 10),
+	})
+	keys := map[string]bool{}
+	for key := range fromFields {
+		keys[key] = true
+	}
+	for key := range toFields {
+		keys[key] = true
+	}
+	var records [][]string
+	for key := range keys {
+		if fromFields[key] != toFields[key] {
+			records = append(records, []string{key, fromFields[key], toFields[key]})
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][0] < records[j][0] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// flattenLaunchTemplateData returns the fields of the data keyed by paths such as NetworkInterfaces[0].Groups[1].
+// The user data is replaced by its hash because the whole script does not fit in a table cell.
+func flattenLaunchTemplateData(data *types.ResponseLaunchTemplateData) (map[string]string, error) {
+	fields := map[string]string{}
+	if data == nil {
+		return fields, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	flattenJson("", v, fields)
+	if userData := aws.ToString(data.UserData); userData != "" {
+		decoded, err := base64.StdEncoding.DecodeString(userData)
+		if err != nil {
+			decoded = []byte(userData)
+		}
+		fields["UserData"] = fmt.Sprintf("sha256:%x", sha256.Sum256(decoded))[:19]
+	}
+	return fields, nil
+}
+
+// flattenJson stores the leaves of the decoded JSON in fields. Null values are skipped.
+func flattenJson(path string, v interface{}, fields map[string]string) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if path == "" {
+				flattenJson(key, child, fields)
+			} else {
+				flattenJson(path+"."+key, child, fields)
+			}
+		}
+	case []interface{}:
+		for i, child := range value {
+			flattenJson(fmt.Sprintf("%s[%d]", path, i), child, fields)
+		}
+	case nil:
+	default:
+		fields[path] = fmt.Sprint(value)
+	}
+}
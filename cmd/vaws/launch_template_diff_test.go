@@ -0,0 +1,105 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+var testLaunchTemplateVersions = []*ec2.DescribeLaunchTemplateVersionsOutput{
+	{
+		LaunchTemplateVersions: []types.LaunchTemplateVersion{
+			{
+				LaunchTemplateData: &types.ResponseLaunchTemplateData{
+					ImageId:      aws.String("ami-22222222"),
+					InstanceType: "t3.medium",
+					KeyName:      aws.String("web-key"),
+					NetworkInterfaces: []types.LaunchTemplateInstanceNetworkInterfaceSpecification{
+						{
+							DeviceIndex: aws.Int32(0),
+							Groups:      []string{"sg-11111111", "sg-22222222"},
+						},
+					},
+					UserData: aws.String("IyEvYmluL2Jhc2gKZWNobyB2Mwo="),
+				},
+				LaunchTemplateName: aws.String("web"),
+				VersionNumber:      aws.Int64(3),
+			},
+			{
+				DefaultVersion: aws.Bool(true),
+				LaunchTemplateData: &types.ResponseLaunchTemplateData{
+					ImageId:      aws.String("ami-11111111"),
+					InstanceType: "t3.small",
+					KeyName:      aws.String("web-key"),
+					NetworkInterfaces: []types.LaunchTemplateInstanceNetworkInterfaceSpecification{
+						{
+							DeviceIndex: aws.Int32(0),
+							Groups:      []string{"sg-11111111"},
+						},
+					},
+					UserData: aws.String("IyEvYmluL2Jhc2gK"),
+				},
+				LaunchTemplateName: aws.String("web"),
+				VersionNumber:      aws.Int64(2),
+			},
+		},
+	},
+}
+
+func Test_showLaunchTemplateDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want string
+	}{
+		{
+			name: "default and latest",
+			from: "$Default",
+			to:   "$Latest",
+			want: `+--------------------------------+---------------------+---------------------+
+|             FIELD              |      VERSION 2      |      VERSION 3      |
++--------------------------------+---------------------+---------------------+
+| ImageId                        | ami-11111111        | ami-22222222        |
+| InstanceType                   | t3.small            | t3.medium           |
+| NetworkInterfaces[0].Groups[1] |                     | sg-22222222         |
+| UserData                       | sha256:b875f928546a | sha256:4e97e94946be |
++--------------------------------+---------------------+---------------------+
+`,
+		},
+		{
+			name: "same version",
+			from: "2",
+			to:   "$Default",
+			want: `+-------+-----------+-----------+
+| FIELD | VERSION 2 | VERSION 2 |
++-------+-----------+-----------+
++-------+-----------+-----------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := findLaunchTemplateVersions(testLaunchTemplateVersions, tt.from, tt.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			showLaunchTemplateDiff(from, to, tablewriter.NewWriter(&buf))
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
+
+func Test_findLaunchTemplateVersions(t *testing.T) {
+	if _, _, err := findLaunchTemplateVersions(testLaunchTemplateVersions, "2", "4"); err == nil {
+		t.Errorf("failed to test: missing version\n")
+	}
+}
@@ -0,0 +1,99 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+func Test_showLaunchTemplates(t *testing.T) {
+	type args struct {
+		outputs      *launchTemplateOutputs
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs: &launchTemplateOutputs{
+					templates: []*ec2.DescribeLaunchTemplatesOutput{
+						{
+							LaunchTemplates: []types.LaunchTemplate{
+								{
+									DefaultVersionNumber: aws.Int64(2),
+									LatestVersionNumber:  aws.Int64(3),
+									LaunchTemplateId:     aws.String("lt-11111111111111111"),
+									LaunchTemplateName:   aws.String("web"),
+								},
+								{
+									DefaultVersionNumber: aws.Int64(1),
+									LatestVersionNumber:  aws.Int64(1),
+									LaunchTemplateId:     aws.String("lt-22222222222222222"),
+									LaunchTemplateName:   aws.String("batch"),
+								},
+							},
+						},
+					},
+					versions: []*ec2.DescribeLaunchTemplateVersionsOutput{
+						{
+							LaunchTemplateVersions: []types.LaunchTemplateVersion{
+								{
+									DefaultVersion: aws.Bool(true),
+									LaunchTemplateData: &types.ResponseLaunchTemplateData{
+										ImageId:          aws.String("ami-11111111"),
+										InstanceType:     "t3.small",
+										SecurityGroupIds: []string{"sg-11111111"},
+										UserData:         aws.String("IyEvYmluL2Jhc2gK"),
+									},
+									LaunchTemplateId: aws.String("lt-11111111111111111"),
+									VersionNumber:    aws.Int64(2),
+								},
+								{
+									DefaultVersion: aws.Bool(true),
+									LaunchTemplateData: &types.ResponseLaunchTemplateData{
+										ImageId:      aws.String("ami-22222222"),
+										InstanceType: "c5.large",
+										NetworkInterfaces: []types.LaunchTemplateInstanceNetworkInterfaceSpecification{
+											{
+												DeviceIndex: aws.Int32(0),
+												Groups:      []string{"sg-22222222", "sg-33333333"},
+											},
+										},
+									},
+									LaunchTemplateId: aws.String("lt-22222222222222222"),
+									VersionNumber:    aws.Int64(1),
+								},
+							},
+						},
+					},
+				},
+				sortPosition: 1,
+			},
+			want: `+-------+----------------------+---------+--------+--------------+----------+-------------------------+-----------+
+| NAME  |          ID          | DEFAULT | LATEST |     AMI      |   TYPE   |     SECURITY GROUP      | USER DATA |
++-------+----------------------+---------+--------+--------------+----------+-------------------------+-----------+
+| batch | lt-22222222222222222 |       1 |      1 | ami-22222222 | c5.large | sg-22222222,sg-33333333 | no        |
+| web   | lt-11111111111111111 |       2 |      3 | ami-11111111 | t3.small | sg-11111111             | yes       |
++-------+----------------------+---------+--------+--------------+----------+-------------------------+-----------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showLaunchTemplates(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
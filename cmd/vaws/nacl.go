@@ -0,0 +1,277 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	naclMaxResult = 1000
+	// naclDefaultRuleNumber is the rule number of the "*" entry that denies everything not matched by other entries.
+	naclDefaultRuleNumber = 32767
+)
+
+// naclCmd represents the nacl command
+var naclCmd = &cobra.Command{
+	Use:   "nacl",
+	Short: "Show network ACLs",
+	Long:  `Show network ACLs`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		outputs, err := getNetworkAcls(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showNetworkAcls(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(naclCmd)
+}
+
+func getNetworkAcls(cfg aws.Config) ([]*ec2.DescribeNetworkAclsOutput, error) {
+	var outputs []*ec2.DescribeNetworkAclsOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeNetworkAcls API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeNetworkAcls(context.TODO(), &ec2.DescribeNetworkAclsInput{
+		MaxResults: aws.Int32(naclMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeNetworkAcls(context.TODO(), &ec2.DescribeNetworkAclsInput{
+			MaxResults: aws.Int32(naclMaxResult),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// showNetworkAcls shows the entries of each network ACL in evaluation order.
+// NOTE highlights entries that allow all traffic and deny entries that shadow later allow entries.
+func showNetworkAcls(outputs []*ec2.DescribeNetworkAclsOutput, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"NAME", "ID", "VPC", "SUBNET", "DIRECTION", "RULE", "PROTOCOL", "PORT", "CIDR", "ACTION", "NOTE"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	var records [][]string
+	for _, o := range outputs {
+		for _, nacl := range o.NetworkAcls {
+			name := getNameTag(nacl.Tags)
+			var subnets []string
+			for _, a := range nacl.Associations {
+				subnets = append(subnets, *a.SubnetId)
+			}
+			for _, egress := range []bool{false, true} {
+				entries := sortedNaclEntries(nacl.Entries, egress)
+				notes := naclEntryNotes(entries)
+				direction := "inbound"
+				if egress {
+					direction = "outbound"
+				}
+				for i, entry := range entries {
+					records = append(records, []string{
+						name,
+						*nacl.NetworkAclId,
+						*nacl.VpcId,
+						joinValues(subnets),
+						direction,
+						naclRuleNumber(entry),
+						naclProtocol(entry.Protocol),
+						naclPortRange(entry),
+						naclCidr(entry),
+						string(entry.RuleAction),
+						joinValues(notes[i]),
+					})
+				}
+			}
+		}
+	}
+	// Keep the evaluation order of entries within the same network ACL
+	sort.SliceStable(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// sortedNaclEntries returns the inbound or outbound entries in ascending rule number, which is the evaluation order.
+func sortedNaclEntries(entries []types.NetworkAclEntry, egress bool) []types.NetworkAclEntry {
+	var sorted []types.NetworkAclEntry
+	for _, entry := range entries {
+		if entry.Egress != nil && *entry.Egress == egress {
+			sorted = append(sorted, entry)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return *sorted[i].RuleNumber < *sorted[j].RuleNumber })
+	return sorted
+}
+
+// naclEntryNotes returns the notes of each entry sorted by sortedNaclEntries.
+func naclEntryNotes(entries []types.NetworkAclEntry) [][]string {
+	notes := make([][]string, len(entries))
+	for i, entry := range entries {
+		if *entry.RuleNumber == naclDefaultRuleNumber {
+			continue
+		}
+		if entry.RuleAction == types.RuleActionAllow && naclProtocol(entry.Protocol) == "all" && isAnyCidr(naclCidr(entry)) {
+			notes[i] = append(notes[i], "default-allow")
+		}
+		if entry.RuleAction != types.RuleActionDeny {
+			continue
+		}
+		var shadowed []string
+		for j := i + 1; j < len(entries); j++ {
+			later := entries[j]
+			if *later.RuleNumber == naclDefaultRuleNumber || later.RuleAction != types.RuleActionAllow {
+				continue
+			}
+			if naclEntryCovers(entry, later) {
+				shadowed = append(shadowed, naclRuleNumber(later))
+				notes[j] = append(notes[j], fmt.Sprintf("shadowed by rule %d", *entry.RuleNumber))
+			}
+		}
+		if len(shadowed) > 0 {
+			notes[i] = append(notes[i], fmt.Sprintf("shadows rule %s", strings.Join(shadowed, ",")))
+		}
+	}
+	return notes
+}
+
+// naclEntryCovers reports whether every packet matched by inner is also matched by outer.
+func naclEntryCovers(outer types.NetworkAclEntry, inner types.NetworkAclEntry) bool {
+	outerProtocol := naclProtocol(outer.Protocol)
+	if outerProtocol != "all" && outerProtocol != naclProtocol(inner.Protocol) {
+		return false
+	}
+	if outerProtocol != "all" && naclHasPorts(outer) {
+		if !naclHasPorts(inner) {
+			return false
+		}
+		if *outer.PortRange.From > *inner.PortRange.From || *outer.PortRange.To < *inner.PortRange.To {
+			return false
+		}
+	}
+	return cidrContains(naclCidr(outer), naclCidr(inner))
+}
+
+func naclHasPorts(entry types.NetworkAclEntry) bool {
+	return entry.PortRange != nil && entry.PortRange.From != nil && entry.PortRange.To != nil
+}
+
+func naclRuleNumber(entry types.NetworkAclEntry) string {
+	if *entry.RuleNumber == naclDefaultRuleNumber {
+		return "*"
+	}
+	return strconv.Itoa(int(*entry.RuleNumber))
+}
+
+// naclProtocol converts the protocol number of network ACLs and security groups to its name.
+func naclProtocol(protocol *string) string {
+	if protocol == nil {
+		return "all"
+	}
+	switch *protocol {
+	case "-1":
+		return "all"
+	case "1":
+		return "icmp"
+	case "6":
+		return "tcp"
+	case "17":
+		return "udp"
+	case "58":
+		return "icmpv6"
+	}
+	return *protocol
+}
+
+func naclPortRange(entry types.NetworkAclEntry) string {
+	if naclProtocol(entry.Protocol) == "all" || !naclHasPorts(entry) {
+		return "all"
+	}
+	if *entry.PortRange.From == *entry.PortRange.To {
+		return strconv.Itoa(int(*entry.PortRange.From))
+	}
+	return fmt.Sprintf("%d-%d", *entry.PortRange.From, *entry.PortRange.To)
+}
+
+func naclCidr(entry types.NetworkAclEntry) string {
+	if entry.CidrBlock != nil {
+		return *entry.CidrBlock
+	}
+	if entry.Ipv6CidrBlock != nil {
+		return *entry.Ipv6CidrBlock
+	}
+	return ""
+}
+
+func isAnyCidr(cidr string) bool {
+	return cidr == "0.0.0.0/0" || cidr == "::/0"
+}
+
+// cidrContains reports whether the outer CIDR contains the whole inner CIDR.
+// A bare IP address is treated as a single host CIDR.
+func cidrContains(outer string, inner string) bool {
+	_, outerNet, err := net.ParseCIDR(toCidr(outer))
+	if err != nil {
+		return false
+	}
+	_, innerNet, err := net.ParseCIDR(toCidr(inner))
+	if err != nil {
+		return false
+	}
+	outerOnes, outerBits := outerNet.Mask.Size()
+	innerOnes, innerBits := innerNet.Mask.Size()
+	return outerBits == innerBits && outerOnes <= innerOnes && outerNet.Contains(innerNet.IP)
+}
+
+func toCidr(address string) string {
+	if strings.Contains(address, "/") {
+		return address
+	}
+	if strings.Contains(address, ":") {
+		return address + "/128"
+	}
+	return address + "/32"
+}
@@ -0,0 +1,171 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+func Test_showNetworkAcls(t *testing.T) {
+	type args struct {
+		outputs      []*ec2.DescribeNetworkAclsOutput
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs: []*ec2.DescribeNetworkAclsOutput{
+					{
+						NetworkAcls: []types.NetworkAcl{
+							{
+								Associations: []types.NetworkAclAssociation{
+									{
+										SubnetId: aws.String("subnet-xxxxxxxx"),
+									},
+								},
+								Entries: []types.NetworkAclEntry{
+									{
+										CidrBlock:  aws.String("0.0.0.0/0"),
+										Egress:     aws.Bool(false),
+										Protocol:   aws.String("-1"),
+										RuleAction: "deny",
+										RuleNumber: aws.Int32(32767),
+									},
+									{
+										CidrBlock: aws.String("10.0.0.0/8"),
+										Egress:    aws.Bool(false),
+										PortRange: &types.PortRange{
+											From: aws.Int32(22),
+											To:   aws.Int32(22),
+										},
+										Protocol:   aws.String("6"),
+										RuleAction: "allow",
+										RuleNumber: aws.Int32(110),
+									},
+									{
+										CidrBlock: aws.String("0.0.0.0/0"),
+										Egress:    aws.Bool(false),
+										PortRange: &types.PortRange{
+											From: aws.Int32(443),
+											To:   aws.Int32(443),
+										},
+										Protocol:   aws.String("6"),
+										RuleAction: "allow",
+										RuleNumber: aws.Int32(100),
+									},
+									{
+										CidrBlock: aws.String("0.0.0.0/0"),
+										Egress:    aws.Bool(false),
+										PortRange: &types.PortRange{
+											From: aws.Int32(0),
+											To:   aws.Int32(1023),
+										},
+										Protocol:   aws.String("6"),
+										RuleAction: "deny",
+										RuleNumber: aws.Int32(90),
+									},
+									{
+										CidrBlock: aws.String("0.0.0.0/0"),
+										Egress:    aws.Bool(true),
+										PortRange: &types.PortRange{
+											From: aws.Int32(1024),
+											To:   aws.Int32(65535),
+										},
+										Protocol:   aws.String("6"),
+										RuleAction: "allow",
+										RuleNumber: aws.Int32(100),
+									},
+									{
+										CidrBlock:  aws.String("0.0.0.0/0"),
+										Egress:     aws.Bool(true),
+										Protocol:   aws.String("-1"),
+										RuleAction: "deny",
+										RuleNumber: aws.Int32(32767),
+									},
+								},
+								NetworkAclId: aws.String("acl-22222222"),
+								Tags: []types.Tag{
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("restricted"),
+									},
+								},
+								VpcId: aws.String("vpc-12345678"),
+							},
+							{
+								Entries: []types.NetworkAclEntry{
+									{
+										CidrBlock:  aws.String("0.0.0.0/0"),
+										Egress:     aws.Bool(false),
+										Protocol:   aws.String("-1"),
+										RuleAction: "allow",
+										RuleNumber: aws.Int32(100),
+									},
+									{
+										CidrBlock:  aws.String("0.0.0.0/0"),
+										Egress:     aws.Bool(false),
+										Protocol:   aws.String("-1"),
+										RuleAction: "deny",
+										RuleNumber: aws.Int32(32767),
+									},
+									{
+										CidrBlock:  aws.String("0.0.0.0/0"),
+										Egress:     aws.Bool(true),
+										Protocol:   aws.String("-1"),
+										RuleAction: "allow",
+										RuleNumber: aws.Int32(100),
+									},
+									{
+										CidrBlock:  aws.String("0.0.0.0/0"),
+										Egress:     aws.Bool(true),
+										Protocol:   aws.String("-1"),
+										RuleAction: "deny",
+										RuleNumber: aws.Int32(32767),
+									},
+								},
+								IsDefault:    aws.Bool(true),
+								NetworkAclId: aws.String("acl-11111111"),
+								VpcId:        aws.String("vpc-12345678"),
+							},
+						},
+					},
+				},
+				sortPosition: 2,
+			},
+			want: `+------------+--------------+--------------+-----------------+-----------+------+----------+------------+------------+--------+----------------------+
+|    NAME    |      ID      |     VPC      |     SUBNET      | DIRECTION | RULE | PROTOCOL |    PORT    |    CIDR    | ACTION |         NOTE         |
++------------+--------------+--------------+-----------------+-----------+------+----------+------------+------------+--------+----------------------+
+|            | acl-11111111 | vpc-12345678 |                 | inbound   |  100 | all      | all        | 0.0.0.0/0  | allow  | default-allow        |
+|            | acl-11111111 | vpc-12345678 |                 | inbound   | *    | all      | all        | 0.0.0.0/0  | deny   |                      |
+|            | acl-11111111 | vpc-12345678 |                 | outbound  |  100 | all      | all        | 0.0.0.0/0  | allow  | default-allow        |
+|            | acl-11111111 | vpc-12345678 |                 | outbound  | *    | all      | all        | 0.0.0.0/0  | deny   |                      |
+| restricted | acl-22222222 | vpc-12345678 | subnet-xxxxxxxx | inbound   |   90 | tcp      | 0-1023     | 0.0.0.0/0  | deny   | shadows rule 100,110 |
+| restricted | acl-22222222 | vpc-12345678 | subnet-xxxxxxxx | inbound   |  100 | tcp      |        443 | 0.0.0.0/0  | allow  | shadowed by rule 90  |
+| restricted | acl-22222222 | vpc-12345678 | subnet-xxxxxxxx | inbound   |  110 | tcp      |         22 | 10.0.0.0/8 | allow  | shadowed by rule 90  |
+| restricted | acl-22222222 | vpc-12345678 | subnet-xxxxxxxx | inbound   | *    | all      | all        | 0.0.0.0/0  | deny   |                      |
+| restricted | acl-22222222 | vpc-12345678 | subnet-xxxxxxxx | outbound  |  100 | tcp      | 1024-65535 | 0.0.0.0/0  | allow  |                      |
+| restricted | acl-22222222 | vpc-12345678 | subnet-xxxxxxxx | outbound  | *    | all      | all        | 0.0.0.0/0  | deny   |                      |
++------------+--------------+--------------+-----------------+-----------+------+----------+------------+------------+--------+----------------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showNetworkAcls(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,638 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"net"
+	"os"
+	"strings"
+)
+
+const (
+	pathInternet = "internet"
+	// Return traffic goes back to the ephemeral port of the client.
+	ephemeralPortFrom = 1024
+	ephemeralPortTo   = 65535
+	// prefixListMaxResults is the maximum of the GetManagedPrefixListEntries API.
+	prefixListMaxResults = 100
+)
+
+// pathOutputs holds the resources that the path command resolves endpoints and hops from.
+type pathOutputs struct {
+	instances      []*ec2.DescribeInstancesOutput
+	loadBalancers  *elasticloadbalancingv2.DescribeLoadBalancersOutput
+	dbInstances    *rds.DescribeDBInstancesOutput
+	subnets        []*ec2.DescribeSubnetsOutput
+	routeTables    []*ec2.DescribeRouteTablesOutput
+	networkAcls    []*ec2.DescribeNetworkAclsOutput
+	securityGroups []*ec2.DescribeSecurityGroupsOutput
+	// networkInterfaces resolve an IP address to the security groups of its network interface
+	networkInterfaces []*ec2.DescribeNetworkInterfacesOutput
+	// prefix list ID -> CIDRs
+	prefixLists map[string][]string
+}
+
+// pathEndpoint is the source or target of a path.
+// cidr is the address used to evaluate routes and rules; it is the subnet CIDR when the exact address is unknown.
+// subnetId is empty for the internet and addresses outside of the VPCs.
+// securityGroupsUnknown is set for an address in a subnet that no network interface has, so its security groups are not evaluated.
+type pathEndpoint struct {
+	label                 string
+	cidr                  string
+	subnetId              string
+	vpcId                 string
+	securityGroupIds      []string
+	securityGroupsUnknown bool
+	public                bool
+}
+
+// pathHop is a network element that the traffic passes through and the rule or route that decided it.
+// unknown means that the hop depends on rules that were not evaluated, so it neither passes nor fails.
+type pathHop struct {
+	hop      string
+	resource string
+	pass     bool
+	unknown  bool
+	reason   string
+}
+
+// pathCmd represents the path command
+var pathCmd = &cobra.Command{
+	Use:   "path <source> <target>",
+	Short: "Analyze the network path between two resources",
+	Long: `Analyze the network path between two resources.
+The source and target are "internet", an IP address, a CIDR, an EC2 name or ID, a load balancer name or an RDS instance identifier.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		port, err := cmd.Flags().GetInt32("port")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		protocolFlg, err := cmd.Flags().GetString("protocol")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		protocol, err := parsePathProtocol(protocolFlg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		outputs, err := getPathOutputs(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		source, err := resolvePathEndpoint(args[0], outputs)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		target, err := resolvePathEndpoint(args[1], outputs)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s -> %s %s/%d\n", source.label, target.label, protocol, port)
+		showPath(analyzePath(source, target, protocol, port, outputs), tablewriter.NewWriter(os.Stdout))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(pathCmd)
+	pathCmd.Flags().Int32("port", 443, "Destination port")
+	pathCmd.Flags().String("protocol", "tcp", "Protocol (tcp, udp)")
+}
+
+// parsePathProtocol accepts tcp and udp in any case, which are the protocols that the rules are evaluated for.
+func parsePathProtocol(flag string) (string, error) {
+	protocol := strings.ToLower(flag)
+	if protocol != "tcp" && protocol != "udp" {
+		return "", fmt.Errorf("unknown protocol %s, use tcp or udp", flag)
+	}
+	return protocol, nil
+}
+
+func getPathOutputs(cfg aws.Config) (*pathOutputs, error) {
+	var err error
+	outputs := &pathOutputs{}
+	outputs.instances, err = getEc2Instances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.loadBalancers, err = getElb(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.dbInstances, err = getRdsInstances(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.subnets, err = getSubnets(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.routeTables, err = getRouteTables(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.networkAcls, err = getNetworkAcls(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.securityGroups, err = getSecurityGroups(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.networkInterfaces, err = getNetworkInterfaces(cfg)
+	if err != nil {
+		return nil, err
+	}
+	outputs.prefixLists, err = getSecurityGroupPrefixLists(cfg, outputs.securityGroups)
+	if err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// getSecurityGroupPrefixLists returns the CIDRs of the prefix lists that the security group rules refer to.
+func getSecurityGroupPrefixLists(cfg aws.Config, securityGroups []*ec2.DescribeSecurityGroupsOutput) (map[string][]string, error) {
+	prefixLists := map[string][]string{}
+	client := ec2.NewFromConfig(cfg)
+	for _, o := range securityGroups {
+		for _, sg := range o.SecurityGroups {
+			for _, permission := range append(append([]types.IpPermission{}, sg.IpPermissions...), sg.IpPermissionsEgress...) {
+				for _, prefixList := range permission.PrefixListIds {
+					id := aws.ToString(prefixList.PrefixListId)
+					if _, ok := prefixLists[id]; ok || id == "" {
+						continue
+					}
+					cidrs, err := getPrefixListCidrs(client, id)
+					if err != nil {
+						return nil, err
+					}
+					prefixLists[id] = cidrs
+				}
+			}
+		}
+	}
+	return prefixLists, nil
+}
+
+func getPrefixListCidrs(client *ec2.Client, prefixListId string) ([]string, error) {
+	cidrs := []string{}
+	var err error
+	output := &ec2.GetManagedPrefixListEntriesOutput{
+		NextToken: aws.String(""),
+	}
+	for output.NextToken != nil {
+		output, err = client.GetManagedPrefixListEntries(context.TODO(), &ec2.GetManagedPrefixListEntriesInput{
+			MaxResults:   aws.Int32(prefixListMaxResults),
+			NextToken:    output.NextToken,
+			PrefixListId: aws.String(prefixListId),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range output.Entries {
+			if entry.Cidr != nil {
+				cidrs = append(cidrs, *entry.Cidr)
+			}
+		}
+	}
+	return cidrs, nil
+}
+
+// resolvePathEndpoint resolves "internet", an IP address, a CIDR, an EC2 name or ID, a load balancer name or an RDS instance identifier.
+func resolvePathEndpoint(name string, outputs *pathOutputs) (*pathEndpoint, error) {
+	if name == pathInternet {
+		return &pathEndpoint{label: pathInternet, cidr: "0.0.0.0/0", public: true}, nil
+	}
+	subnets := map[string]types.Subnet{}
+	for _, o := range outputs.subnets {
+		for _, subnet := range o.Subnets {
+			subnets[*subnet.SubnetId] = subnet
+		}
+	}
+	var instances []types.Instance
+	for _, o := range outputs.instances {
+		for _, r := range o.Reservations {
+			for _, instance := range r.Instances {
+				if instance.State != nil && instance.State.Name == types.InstanceStateNameTerminated {
+					continue
+				}
+				if *instance.InstanceId != name && getNameTag(instance.Tags) != name &&
+					(instance.PrivateIpAddress == nil || *instance.PrivateIpAddress != name) {
+					continue
+				}
+				instances = append(instances, instance)
+			}
+		}
+	}
+	if len(instances) > 1 {
+		var instanceIds []string
+		for _, instance := range instances {
+			instanceIds = append(instanceIds, *instance.InstanceId)
+		}
+		return nil, fmt.Errorf("ambiguous name %s matches %s, use an instance ID", name, strings.Join(instanceIds, ","))
+	}
+	if len(instances) == 1 {
+		instance := instances[0]
+		if instance.PrivateIpAddress == nil || instance.SubnetId == nil {
+			return nil, fmt.Errorf("%s has no private IP address", name)
+		}
+		endpoint := &pathEndpoint{
+			label:    formatNameId(getNameTag(instance.Tags), *instance.InstanceId),
+			cidr:     toCidr(*instance.PrivateIpAddress),
+			subnetId: *instance.SubnetId,
+			vpcId:    *instance.VpcId,
+			public:   instance.PublicIpAddress != nil,
+		}
+		for _, sg := range instance.SecurityGroups {
+			endpoint.securityGroupIds = append(endpoint.securityGroupIds, *sg.GroupId)
+		}
+		return endpoint, nil
+	}
+	if outputs.loadBalancers != nil {
+		for _, lb := range outputs.loadBalancers.LoadBalancers {
+			if *lb.LoadBalancerName != name || len(lb.AvailabilityZones) == 0 {
+				continue
+			}
+			subnet, ok := subnets[*lb.AvailabilityZones[0].SubnetId]
+			if !ok {
+				return nil, fmt.Errorf("subnet of %s is not found", name)
+			}
+			return &pathEndpoint{
+				label:            name,
+				cidr:             *subnet.CidrBlock,
+				subnetId:         *subnet.SubnetId,
+				vpcId:            *subnet.VpcId,
+				securityGroupIds: lb.SecurityGroups,
+				public:           lb.Scheme == "internet-facing",
+			}, nil
+		}
+	}
+	if outputs.dbInstances != nil {
+		for _, db := range outputs.dbInstances.DBInstances {
+			if *db.DBInstanceIdentifier != name || db.DBSubnetGroup == nil || len(db.DBSubnetGroup.Subnets) == 0 {
+				continue
+			}
+			// Use the subnet of the AZ where the instance is running
+			subnetId := *db.DBSubnetGroup.Subnets[0].SubnetIdentifier
+			for _, s := range db.DBSubnetGroup.Subnets {
+				if db.AvailabilityZone != nil && s.SubnetAvailabilityZone != nil && *s.SubnetAvailabilityZone.Name == *db.AvailabilityZone {
+					subnetId = *s.SubnetIdentifier
+				}
+			}
+			subnet, ok := subnets[subnetId]
+			if !ok {
+				return nil, fmt.Errorf("subnet of %s is not found", name)
+			}
+			endpoint := &pathEndpoint{
+				label:    name,
+				cidr:     *subnet.CidrBlock,
+				subnetId: subnetId,
+				vpcId:    *subnet.VpcId,
+				public:   db.PubliclyAccessible,
+			}
+			for _, sg := range db.VpcSecurityGroups {
+				endpoint.securityGroupIds = append(endpoint.securityGroupIds, *sg.VpcSecurityGroupId)
+			}
+			return endpoint, nil
+		}
+	}
+	cidr := toCidr(name)
+	if _, _, err := net.ParseCIDR(cidr); err != nil {
+		return nil, fmt.Errorf("%s is not found", name)
+	}
+	// An address of RDS, Lambda or a VPC endpoint belongs to a network interface with the security groups
+	for _, o := range outputs.networkInterfaces {
+		for _, eni := range o.NetworkInterfaces {
+			for _, ip := range eniPrivateIps(eni) {
+				if ip != name || eni.SubnetId == nil {
+					continue
+				}
+				endpoint := &pathEndpoint{
+					label:    fmt.Sprintf("%s(%s)", name, *eni.NetworkInterfaceId),
+					cidr:     cidr,
+					subnetId: *eni.SubnetId,
+					vpcId:    aws.ToString(eni.VpcId),
+					public:   len(eniPublicIps(eni)) > 0,
+				}
+				for _, group := range eni.Groups {
+					endpoint.securityGroupIds = append(endpoint.securityGroupIds, *group.GroupId)
+				}
+				return endpoint, nil
+			}
+		}
+	}
+	for _, subnet := range subnets {
+		if subnet.CidrBlock != nil && cidrContains(*subnet.CidrBlock, cidr) {
+			return &pathEndpoint{
+				label:                 fmt.Sprintf("%s(%s)", name, *subnet.SubnetId),
+				cidr:                  cidr,
+				subnetId:              *subnet.SubnetId,
+				vpcId:                 *subnet.VpcId,
+				securityGroupsUnknown: true,
+			}, nil
+		}
+	}
+	return &pathEndpoint{label: name, cidr: cidr, public: true}, nil
+}
+
+// analyzePath evaluates the security groups, network ACLs and route tables from the source to the target,
+// and the network ACLs and route tables of the return traffic. Security groups are stateful and allow the return traffic.
+func analyzePath(source *pathEndpoint, target *pathEndpoint, protocol string, port int32, outputs *pathOutputs) []pathHop {
+	subnetRouteTables := getSubnetRouteTables(outputs.subnets, outputs.routeTables)
+	subnetNetworkAcls := getSubnetNetworkAcls(outputs.networkAcls)
+	securityGroups := map[string]types.SecurityGroup{}
+	for _, o := range outputs.securityGroups {
+		for _, sg := range o.SecurityGroups {
+			securityGroups[*sg.GroupId] = sg
+		}
+	}
+	crossSubnet := source.subnetId != target.subnetId
+	var hops []pathHop
+	if source.subnetId != "" {
+		hops = append(hops, evaluateSecurityGroups("security group (outbound)", source, true, protocol, port, target, securityGroups, outputs.prefixLists))
+		if crossSubnet {
+			hops = append(hops, evaluateNetworkAcl("network ACL (outbound)", subnetNetworkAcls[source.subnetId], true, protocol, port, port, target.cidr))
+		}
+		hops = append(hops, evaluateRoute("route", subnetRouteTables[source.subnetId], source, target))
+	} else if target.subnetId != "" {
+		hops = append(hops, evaluateInternetGateway("internet gateway", subnetRouteTables[target.subnetId], target))
+	}
+	if target.subnetId != "" {
+		if crossSubnet {
+			hops = append(hops, evaluateNetworkAcl("network ACL (inbound)", subnetNetworkAcls[target.subnetId], false, protocol, port, port, source.cidr))
+		}
+		hops = append(hops, evaluateSecurityGroups("security group (inbound)", target, false, protocol, port, source, securityGroups, outputs.prefixLists))
+		if crossSubnet {
+			hops = append(hops, evaluateNetworkAcl("network ACL (outbound, return)", subnetNetworkAcls[target.subnetId], true, protocol, ephemeralPortFrom, ephemeralPortTo, source.cidr))
+		}
+		// The internet gateway hop already covers the return route to the internet
+		if source.subnetId != "" && source.vpcId != target.vpcId {
+			hops = append(hops, evaluateRoute("route (return)", subnetRouteTables[target.subnetId], target, source))
+		}
+	}
+	if source.subnetId != "" && crossSubnet {
+		hops = append(hops, evaluateNetworkAcl("network ACL (inbound, return)", subnetNetworkAcls[source.subnetId], false, protocol, ephemeralPortFrom, ephemeralPortTo, target.cidr))
+	}
+	return hops
+}
+
+func showPath(hops []pathHop, table *tablewriter.Table) {
+	table.SetHeader([]string{"STEP", "HOP", "RESOURCE", "RESULT", "REASON"})
+	table.SetAutoWrapText(false)
+	var records [][]string
+	for i, hop := range hops {
+		result := "pass"
+		if hop.unknown {
+			result = "unknown"
+		} else if !hop.pass {
+			result = "fail"
+		}
+		records = append(records, []string{fmt.Sprintf("%d", i+1), hop.hop, hop.resource, result, hop.reason})
+	}
+	table.AppendBulk(records)
+	table.Render()
+}
+
+// naclProtocolPort renders the protocol and port range of the entry such as "tcp/443" or "all".
+func naclProtocolPort(entry types.NetworkAclEntry) string {
+	protocol := naclProtocol(entry.Protocol)
+	if protocol == "all" || !naclHasPorts(entry) {
+		return protocol
+	}
+	return fmt.Sprintf("%s/%s", protocol, naclPortRange(entry))
+}
+
+// getSubnetNetworkAcls returns the network ACL of each subnet ID.
+func getSubnetNetworkAcls(outputs []*ec2.DescribeNetworkAclsOutput) map[string]types.NetworkAcl {
+	nacls := map[string]types.NetworkAcl{}
+	for _, o := range outputs {
+		for _, nacl := range o.NetworkAcls {
+			for _, a := range nacl.Associations {
+				nacls[*a.SubnetId] = nacl
+			}
+		}
+	}
+	return nacls
+}
+
+// evaluateNetworkAcl finds the first entry in rule number order that matches the whole port range from or to the CIDR.
+// The result is unknown when earlier entries overlapping only part of the range disagree with it, or when no entry covers the whole range but some allow part of it.
+func evaluateNetworkAcl(hop string, nacl types.NetworkAcl, egress bool, protocol string, portFrom int32, portTo int32, cidr string) pathHop {
+	if nacl.NetworkAclId == nil {
+		return pathHop{hop: hop, pass: false, reason: "network ACL is not found"}
+	}
+	ports := fmt.Sprintf("%d", portFrom)
+	if portFrom != portTo {
+		ports = fmt.Sprintf("%d-%d", portFrom, portTo)
+	}
+	// Entries that cover only part of the ports decide some of them, such as the ephemeral ports of the return traffic
+	var partialAllows, partialDenies []string
+	for _, entry := range sortedNaclEntries(nacl.Entries, egress) {
+		entryProtocol := naclProtocol(entry.Protocol)
+		if entryProtocol != "all" && entryProtocol != protocol {
+			continue
+		}
+		if !cidrContains(naclCidr(entry), cidr) {
+			continue
+		}
+		reason := fmt.Sprintf("rule %s %s %s %s", naclRuleNumber(entry), entry.RuleAction, naclProtocolPort(entry), naclCidr(entry))
+		if entryProtocol != "all" && naclHasPorts(entry) {
+			if portTo < *entry.PortRange.From || *entry.PortRange.To < portFrom {
+				continue
+			}
+			if portFrom < *entry.PortRange.From || *entry.PortRange.To < portTo {
+				if entry.RuleAction == types.RuleActionAllow {
+					partialAllows = append(partialAllows, reason)
+				} else {
+					partialDenies = append(partialDenies, reason)
+				}
+				continue
+			}
+		}
+		allow := entry.RuleAction == types.RuleActionAllow
+		if allow && len(partialDenies) > 0 {
+			return pathHop{hop: hop, resource: *nacl.NetworkAclId, unknown: true, reason: fmt.Sprintf("%s, but %s", reason, strings.Join(partialDenies, ", "))}
+		}
+		if !allow && len(partialAllows) > 0 {
+			return pathHop{hop: hop, resource: *nacl.NetworkAclId, unknown: true, reason: fmt.Sprintf("%s, but %s", reason, strings.Join(partialAllows, ", "))}
+		}
+		return pathHop{hop: hop, resource: *nacl.NetworkAclId, pass: allow, reason: reason}
+	}
+	if len(partialAllows) > 0 {
+		return pathHop{hop: hop, resource: *nacl.NetworkAclId, unknown: true, reason: fmt.Sprintf("%s covers only part of %s", strings.Join(partialAllows, ", "), ports)}
+	}
+	return pathHop{hop: hop, resource: *nacl.NetworkAclId, pass: false, reason: fmt.Sprintf("no entry matches %s %s %s", protocol, ports, cidr)}
+}
+
+// evaluateSecurityGroups finds a rule of the security groups of the endpoint that allows the port from or to the peer.
+// A peer is matched by an IPv4 or IPv6 CIDR, a prefix list or one of its security groups.
+func evaluateSecurityGroups(hop string, endpoint *pathEndpoint, egress bool, protocol string, port int32, peer *pathEndpoint, securityGroups map[string]types.SecurityGroup, prefixLists map[string][]string) pathHop {
+	if endpoint.securityGroupsUnknown {
+		return pathHop{hop: hop, unknown: true, reason: fmt.Sprintf("security groups of %s are unknown", endpoint.label)}
+	}
+	groupIds := endpoint.securityGroupIds
+	resource := joinValues(groupIds)
+	// Such as a Network Load Balancer without security groups
+	if len(groupIds) == 0 {
+		return pathHop{hop: hop, pass: true, reason: "no security groups"}
+	}
+	// prefix lists whose entries are unknown
+	var notEvaluated []string
+	peerGroups := map[string]bool{}
+	for _, id := range peer.securityGroupIds {
+		peerGroups[id] = true
+	}
+	for _, id := range groupIds {
+		sg, ok := securityGroups[id]
+		if !ok {
+			continue
+		}
+		permissions := sg.IpPermissions
+		if egress {
+			permissions = sg.IpPermissionsEgress
+		}
+		for _, permission := range permissions {
+			permissionProtocol := naclProtocol(permission.IpProtocol)
+			if permissionProtocol != "all" && permissionProtocol != protocol {
+				continue
+			}
+			if permissionProtocol != "all" && permission.FromPort != nil && permission.ToPort != nil &&
+				(port < *permission.FromPort || *permission.ToPort < port) {
+				continue
+			}
+			for _, ipRange := range permission.IpRanges {
+				if cidrContains(*ipRange.CidrIp, peer.cidr) {
+					return pathHop{hop: hop, resource: resource, pass: true, reason: fmt.Sprintf("%s allows %s", id, *ipRange.CidrIp)}
+				}
+			}
+			// IPv6 ranges never contain an IPv4 peer because cidrContains compares the address lengths
+			for _, ipRange := range permission.Ipv6Ranges {
+				if ipRange.CidrIpv6 != nil && cidrContains(*ipRange.CidrIpv6, peer.cidr) {
+					return pathHop{hop: hop, resource: resource, pass: true, reason: fmt.Sprintf("%s allows %s", id, *ipRange.CidrIpv6)}
+				}
+			}
+			for _, prefixList := range permission.PrefixListIds {
+				prefixListId := aws.ToString(prefixList.PrefixListId)
+				cidrs, ok := prefixLists[prefixListId]
+				if !ok {
+					notEvaluated = append(notEvaluated, prefixListId)
+					continue
+				}
+				for _, cidr := range cidrs {
+					if cidrContains(cidr, peer.cidr) {
+						return pathHop{hop: hop, resource: resource, pass: true, reason: fmt.Sprintf("%s allows %s(%s)", id, prefixListId, cidr)}
+					}
+				}
+			}
+			for _, pair := range permission.UserIdGroupPairs {
+				if pair.GroupId != nil && peerGroups[*pair.GroupId] {
+					return pathHop{hop: hop, resource: resource, pass: true, reason: fmt.Sprintf("%s allows %s", id, *pair.GroupId)}
+				}
+			}
+		}
+	}
+	reason := fmt.Sprintf("no rule allows %s %d for %s", protocol, port, peer.cidr)
+	if len(notEvaluated) > 0 {
+		return pathHop{hop: hop, resource: resource, unknown: true, reason: fmt.Sprintf("%s, %s not evaluated", reason, strings.Join(notEvaluated, ","))}
+	}
+	return pathHop{hop: hop, resource: resource, pass: false, reason: reason}
+}
+
+// evaluateRoute finds the longest prefix match route from the endpoint to the peer.
+// Traffic to an address outside of the VPCs must go through a NAT, or an internet gateway with a public IP address.
+func evaluateRoute(hop string, rt types.RouteTable, endpoint *pathEndpoint, peer *pathEndpoint) pathHop {
+	if rt.RouteTableId == nil {
+		return pathHop{hop: hop, pass: false, reason: "route table is not found"}
+	}
+	route, ok := lookupRoute(rt, peer.cidr)
+	if !ok {
+		return pathHop{hop: hop, resource: *rt.RouteTableId, pass: false, reason: fmt.Sprintf("no route to %s", peer.cidr)}
+	}
+	target := routeTarget(route)
+	reason := fmt.Sprintf("%s via %s", routeDestination(route), target)
+	if route.State == types.RouteStateBlackhole {
+		return pathHop{hop: hop, resource: *rt.RouteTableId, pass: false, reason: reason + " (blackhole)"}
+	}
+	if strings.HasPrefix(target, "igw-") && !endpoint.public {
+		return pathHop{hop: hop, resource: *rt.RouteTableId, pass: false, reason: reason + " without public IP address"}
+	}
+	if peer.subnetId == "" && target == "local" {
+		return pathHop{hop: hop, resource: *rt.RouteTableId, pass: false, reason: reason}
+	}
+	return pathHop{hop: hop, resource: *rt.RouteTableId, pass: true, reason: reason}
+}
+
+// evaluateInternetGateway checks that traffic from the internet can reach the endpoint.
+func evaluateInternetGateway(hop string, rt types.RouteTable, endpoint *pathEndpoint) pathHop {
+	if rt.RouteTableId == nil {
+		return pathHop{hop: hop, pass: false, reason: "route table is not found"}
+	}
+	if routeTableTier(rt) != subnetTierPublic {
+		return pathHop{hop: hop, resource: *rt.RouteTableId, pass: false, reason: "no default route to an internet gateway"}
+	}
+	if !endpoint.public {
+		return pathHop{hop: hop, resource: *rt.RouteTableId, pass: false, reason: fmt.Sprintf("%s is not public", endpoint.label)}
+	}
+	// The table may be public only by a default route of the other address family
+	defaultRoute := "0.0.0.0/0"
+	if strings.Contains(endpoint.cidr, ":") {
+		defaultRoute = "::/0"
+	}
+	route, ok := lookupRoute(rt, defaultRoute)
+	if !ok || !strings.HasPrefix(routeTarget(route), "igw-") {
+		return pathHop{hop: hop, resource: *rt.RouteTableId, pass: false, reason: fmt.Sprintf("no %s route to an internet gateway", defaultRoute)}
+	}
+	return pathHop{hop: hop, resource: *rt.RouteTableId, pass: true, reason: fmt.Sprintf("%s via %s", routeDestination(route), routeTarget(route))}
+}
+
+// lookupRoute returns the most specific route whose destination contains the CIDR.
+// IPv4 and IPv6 destinations are matched against a CIDR of the same family.
+// Routes to prefix lists are ignored because their CIDRs are not resolved.
+func lookupRoute(rt types.RouteTable, cidr string) (types.Route, bool) {
+	var found types.Route
+	longest := -1
+	for _, route := range rt.Routes {
+		destination := aws.ToString(route.DestinationCidrBlock)
+		if destination == "" {
+			destination = aws.ToString(route.DestinationIpv6CidrBlock)
+		}
+		if destination == "" || !cidrContains(destination, cidr) {
+			continue
+		}
+		_, network, err := net.ParseCIDR(destination)
+		if err != nil {
+			continue
+		}
+		ones, _ := network.Mask.Size()
+		if ones > longest {
+			found = route
+			longest = ones
+		}
+	}
+	return found, longest >= 0
+}
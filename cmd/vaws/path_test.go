@@ -0,0 +1,664 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+var testPathOutputs = &pathOutputs{
+	instances: []*ec2.DescribeInstancesOutput{
+		{
+			Reservations: []types.Reservation{
+				{
+					Instances: []types.Instance{
+						{
+							InstanceId:       aws.String("i-11111111"),
+							PrivateIpAddress: aws.String("10.0.0.10"),
+							PublicIpAddress:  aws.String("35.73.127.100"),
+							SecurityGroups: []types.GroupIdentifier{
+								{
+									GroupId:   aws.String("sg-web"),
+									GroupName: aws.String("web"),
+								},
+							},
+							State: &types.InstanceState{
+								Name: "running",
+							},
+							SubnetId: aws.String("subnet-public"),
+							Tags: []types.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("web01"),
+								},
+							},
+							VpcId: aws.String("vpc-12345678"),
+						},
+						{
+							InstanceId:       aws.String("i-22222222"),
+							PrivateIpAddress: aws.String("10.0.1.10"),
+							SecurityGroups: []types.GroupIdentifier{
+								{
+									GroupId:   aws.String("sg-db"),
+									GroupName: aws.String("db"),
+								},
+							},
+							State: &types.InstanceState{
+								Name: "running",
+							},
+							SubnetId: aws.String("subnet-private"),
+							Tags: []types.Tag{
+								{
+									Key:   aws.String("Name"),
+									Value: aws.String("db01"),
+								},
+							},
+							VpcId: aws.String("vpc-12345678"),
+						},
+					},
+				},
+			},
+		},
+	},
+	subnets: []*ec2.DescribeSubnetsOutput{
+		{
+			Subnets: []types.Subnet{
+				{
+					CidrBlock: aws.String("10.0.0.0/24"),
+					SubnetId:  aws.String("subnet-public"),
+					VpcId:     aws.String("vpc-12345678"),
+				},
+				{
+					CidrBlock: aws.String("10.0.1.0/24"),
+					SubnetId:  aws.String("subnet-private"),
+					VpcId:     aws.String("vpc-12345678"),
+				},
+			},
+		},
+	},
+	routeTables: []*ec2.DescribeRouteTablesOutput{
+		{
+			RouteTables: []types.RouteTable{
+				{
+					Associations: []types.RouteTableAssociation{
+						{
+							SubnetId: aws.String("subnet-public"),
+						},
+					},
+					RouteTableId: aws.String("rtb-public"),
+					Routes: []types.Route{
+						{
+							DestinationCidrBlock: aws.String("10.0.0.0/16"),
+							GatewayId:            aws.String("local"),
+							State:                "active",
+						},
+						{
+							DestinationCidrBlock: aws.String("0.0.0.0/0"),
+							GatewayId:            aws.String("igw-12345678"),
+							State:                "active",
+						},
+					},
+					VpcId: aws.String("vpc-12345678"),
+				},
+				{
+					Associations: []types.RouteTableAssociation{
+						{
+							Main: aws.Bool(true),
+						},
+					},
+					RouteTableId: aws.String("rtb-private"),
+					Routes: []types.Route{
+						{
+							DestinationCidrBlock: aws.String("10.0.0.0/16"),
+							GatewayId:            aws.String("local"),
+							State:                "active",
+						},
+						{
+							DestinationCidrBlock: aws.String("0.0.0.0/0"),
+							NatGatewayId:         aws.String("nat-12345678"),
+							State:                "active",
+						},
+					},
+					VpcId: aws.String("vpc-12345678"),
+				},
+			},
+		},
+	},
+	networkAcls: []*ec2.DescribeNetworkAclsOutput{
+		{
+			NetworkAcls: []types.NetworkAcl{
+				{
+					Associations: []types.NetworkAclAssociation{
+						{
+							SubnetId: aws.String("subnet-public"),
+						},
+					},
+					Entries: []types.NetworkAclEntry{
+						{
+							CidrBlock:  aws.String("0.0.0.0/0"),
+							Egress:     aws.Bool(false),
+							Protocol:   aws.String("-1"),
+							RuleAction: "allow",
+							RuleNumber: aws.Int32(100),
+						},
+						{
+							CidrBlock:  aws.String("0.0.0.0/0"),
+							Egress:     aws.Bool(true),
+							Protocol:   aws.String("-1"),
+							RuleAction: "allow",
+							RuleNumber: aws.Int32(100),
+						},
+					},
+					NetworkAclId: aws.String("acl-public"),
+					VpcId:        aws.String("vpc-12345678"),
+				},
+				{
+					Associations: []types.NetworkAclAssociation{
+						{
+							SubnetId: aws.String("subnet-private"),
+						},
+					},
+					Entries: []types.NetworkAclEntry{
+						{
+							CidrBlock: aws.String("10.0.0.0/16"),
+							Egress:    aws.Bool(false),
+							PortRange: &types.PortRange{
+								From: aws.Int32(3306),
+								To:   aws.Int32(3306),
+							},
+							Protocol:   aws.String("6"),
+							RuleAction: "allow",
+							RuleNumber: aws.Int32(100),
+						},
+						{
+							CidrBlock:  aws.String("0.0.0.0/0"),
+							Egress:     aws.Bool(false),
+							Protocol:   aws.String("-1"),
+							RuleAction: "deny",
+							RuleNumber: aws.Int32(32767),
+						},
+						{
+							CidrBlock:  aws.String("10.0.0.0/16"),
+							Egress:     aws.Bool(true),
+							Protocol:   aws.String("-1"),
+							RuleAction: "allow",
+							RuleNumber: aws.Int32(100),
+						},
+						{
+							CidrBlock:  aws.String("0.0.0.0/0"),
+							Egress:     aws.Bool(true),
+							Protocol:   aws.String("-1"),
+							RuleAction: "deny",
+							RuleNumber: aws.Int32(32767),
+						},
+					},
+					NetworkAclId: aws.String("acl-private"),
+					VpcId:        aws.String("vpc-12345678"),
+				},
+			},
+		},
+	},
+	securityGroups: []*ec2.DescribeSecurityGroupsOutput{
+		{
+			SecurityGroups: []types.SecurityGroup{
+				{
+					GroupId: aws.String("sg-web"),
+					IpPermissions: []types.IpPermission{
+						{
+							FromPort:   aws.Int32(443),
+							IpProtocol: aws.String("tcp"),
+							IpRanges: []types.IpRange{
+								{
+									CidrIp: aws.String("0.0.0.0/0"),
+								},
+							},
+							ToPort: aws.Int32(443),
+						},
+					},
+					IpPermissionsEgress: []types.IpPermission{
+						{
+							IpProtocol: aws.String("-1"),
+							IpRanges: []types.IpRange{
+								{
+									CidrIp: aws.String("0.0.0.0/0"),
+								},
+							},
+						},
+					},
+				},
+				{
+					GroupId: aws.String("sg-db"),
+					IpPermissions: []types.IpPermission{
+						{
+							FromPort:   aws.Int32(3306),
+							IpProtocol: aws.String("tcp"),
+							ToPort:     aws.Int32(3306),
+							UserIdGroupPairs: []types.UserIdGroupPair{
+								{
+									GroupId: aws.String("sg-web"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	networkInterfaces: []*ec2.DescribeNetworkInterfacesOutput{
+		{
+			NetworkInterfaces: []types.NetworkInterface{
+				{
+					Groups: []types.GroupIdentifier{
+						{
+							GroupId: aws.String("sg-db"),
+						},
+					},
+					NetworkInterfaceId: aws.String("eni-rds"),
+					PrivateIpAddress:   aws.String("10.0.1.20"),
+					SubnetId:           aws.String("subnet-private"),
+					VpcId:              aws.String("vpc-12345678"),
+				},
+			},
+		},
+	},
+}
+
+func Test_showPath(t *testing.T) {
+	type args struct {
+		source   string
+		target   string
+		protocol string
+		port     int32
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "internet to public instance",
+			args: args{
+				source:   "internet",
+				target:   "web01",
+				protocol: "tcp",
+				port:     443,
+			},
+			want: `+------+--------------------------------+------------+--------+------------------------------+
+| STEP |              HOP               |  RESOURCE  | RESULT |            REASON            |
++------+--------------------------------+------------+--------+------------------------------+
+|    1 | internet gateway               | rtb-public | pass   | 0.0.0.0/0 via igw-12345678   |
+|    2 | network ACL (inbound)          | acl-public | pass   | rule 100 allow all 0.0.0.0/0 |
+|    3 | security group (inbound)       | sg-web     | pass   | sg-web allows 0.0.0.0/0      |
+|    4 | network ACL (outbound, return) | acl-public | pass   | rule 100 allow all 0.0.0.0/0 |
++------+--------------------------------+------------+--------+------------------------------+
+`,
+		},
+		{
+			name: "instance to instance by security group",
+			args: args{
+				source:   "web01",
+				target:   "i-22222222",
+				protocol: "tcp",
+				port:     3306,
+			},
+			want: `+------+--------------------------------+-------------+--------+-------------------------------------+
+| STEP |              HOP               |  RESOURCE   | RESULT |               REASON                |
++------+--------------------------------+-------------+--------+-------------------------------------+
+|    1 | security group (outbound)      | sg-web      | pass   | sg-web allows 0.0.0.0/0             |
+|    2 | network ACL (outbound)         | acl-public  | pass   | rule 100 allow all 0.0.0.0/0        |
+|    3 | route                          | rtb-public  | pass   | 10.0.0.0/16 via local               |
+|    4 | network ACL (inbound)          | acl-private | pass   | rule 100 allow tcp/3306 10.0.0.0/16 |
+|    5 | security group (inbound)       | sg-db       | pass   | sg-db allows sg-web                 |
+|    6 | network ACL (outbound, return) | acl-private | pass   | rule 100 allow all 10.0.0.0/16      |
+|    7 | network ACL (inbound, return)  | acl-public  | pass   | rule 100 allow all 0.0.0.0/0        |
++------+--------------------------------+-------------+--------+-------------------------------------+
+`,
+		},
+		{
+			name: "internet to private instance",
+			args: args{
+				source:   "internet",
+				target:   "10.0.1.10",
+				protocol: "tcp",
+				port:     3306,
+			},
+			want: `+------+--------------------------------+-------------+--------+-----------------------------------------+
+| STEP |              HOP               |  RESOURCE   | RESULT |                 REASON                  |
++------+--------------------------------+-------------+--------+-----------------------------------------+
+|    1 | internet gateway               | rtb-private | fail   | no default route to an internet gateway |
+|    2 | network ACL (inbound)          | acl-private | fail   | rule * deny all 0.0.0.0/0               |
+|    3 | security group (inbound)       | sg-db       | fail   | no rule allows tcp 3306 for 0.0.0.0/0   |
+|    4 | network ACL (outbound, return) | acl-private | fail   | rule * deny all 0.0.0.0/0               |
++------+--------------------------------+-------------+--------+-----------------------------------------+
+`,
+		},
+		{
+			name: "private instance to the internet",
+			args: args{
+				source:   "db01",
+				target:   "8.8.8.8",
+				protocol: "tcp",
+				port:     443,
+			},
+			want: `+------+-------------------------------+-------------+--------+---------------------------------------+
+| STEP |              HOP              |  RESOURCE   | RESULT |                REASON                 |
++------+-------------------------------+-------------+--------+---------------------------------------+
+|    1 | security group (outbound)     | sg-db       | fail   | no rule allows tcp 443 for 8.8.8.8/32 |
+|    2 | network ACL (outbound)        | acl-private | fail   | rule * deny all 0.0.0.0/0             |
+|    3 | route                         | rtb-private | pass   | 0.0.0.0/0 via nat-12345678            |
+|    4 | network ACL (inbound, return) | acl-private | fail   | rule * deny all 0.0.0.0/0             |
++------+-------------------------------+-------------+--------+---------------------------------------+
+`,
+		},
+		{
+			name: "instance to network interface address",
+			args: args{
+				source:   "web01",
+				target:   "10.0.1.20",
+				protocol: "tcp",
+				port:     3306,
+			},
+			want: `+------+--------------------------------+-------------+--------+-------------------------------------+
+| STEP |              HOP               |  RESOURCE   | RESULT |               REASON                |
++------+--------------------------------+-------------+--------+-------------------------------------+
+|    1 | security group (outbound)      | sg-web      | pass   | sg-web allows 0.0.0.0/0             |
+|    2 | network ACL (outbound)         | acl-public  | pass   | rule 100 allow all 0.0.0.0/0        |
+|    3 | route                          | rtb-public  | pass   | 10.0.0.0/16 via local               |
+|    4 | network ACL (inbound)          | acl-private | pass   | rule 100 allow tcp/3306 10.0.0.0/16 |
+|    5 | security group (inbound)       | sg-db       | pass   | sg-db allows sg-web                 |
+|    6 | network ACL (outbound, return) | acl-private | pass   | rule 100 allow all 10.0.0.0/16      |
+|    7 | network ACL (inbound, return)  | acl-public  | pass   | rule 100 allow all 0.0.0.0/0        |
++------+--------------------------------+-------------+--------+-------------------------------------+
+`,
+		},
+		{
+			name: "instance to unknown address",
+			args: args{
+				source:   "web01",
+				target:   "10.0.1.30",
+				protocol: "tcp",
+				port:     3306,
+			},
+			want: `+------+--------------------------------+-------------+---------+----------------------------------------------------------+
+| STEP |              HOP               |  RESOURCE   | RESULT  |                          REASON                          |
++------+--------------------------------+-------------+---------+----------------------------------------------------------+
+|    1 | security group (outbound)      | sg-web      | pass    | sg-web allows 0.0.0.0/0                                  |
+|    2 | network ACL (outbound)         | acl-public  | pass    | rule 100 allow all 0.0.0.0/0                             |
+|    3 | route                          | rtb-public  | pass    | 10.0.0.0/16 via local                                    |
+|    4 | network ACL (inbound)          | acl-private | pass    | rule 100 allow tcp/3306 10.0.0.0/16                      |
+|    5 | security group (inbound)       |             | unknown | security groups of 10.0.1.30(subnet-private) are unknown |
+|    6 | network ACL (outbound, return) | acl-private | pass    | rule 100 allow all 10.0.0.0/16                           |
+|    7 | network ACL (inbound, return)  | acl-public  | pass    | rule 100 allow all 0.0.0.0/0                             |
++------+--------------------------------+-------------+---------+----------------------------------------------------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			source, err := resolvePathEndpoint(tt.args.source, testPathOutputs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			target, err := resolvePathEndpoint(tt.args.target, testPathOutputs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			showPath(analyzePath(source, target, tt.args.protocol, tt.args.port, testPathOutputs), tablewriter.NewWriter(&buf))
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
+
+func Test_evaluateSecurityGroups(t *testing.T) {
+	securityGroups := map[string]types.SecurityGroup{
+		"sg-app": {
+			GroupId: aws.String("sg-app"),
+			IpPermissions: []types.IpPermission{
+				{
+					FromPort:   aws.Int32(443),
+					IpProtocol: aws.String("tcp"),
+					Ipv6Ranges: []types.Ipv6Range{
+						{
+							CidrIpv6: aws.String("2001:db8::/32"),
+						},
+					},
+					PrefixListIds: []types.PrefixListId{
+						{
+							PrefixListId: aws.String("pl-office"),
+						},
+						{
+							PrefixListId: aws.String("pl-unknown"),
+						},
+					},
+					ToPort: aws.Int32(443),
+				},
+			},
+		},
+	}
+	prefixLists := map[string][]string{
+		"pl-office": {"198.51.100.0/24"},
+	}
+	tests := []struct {
+		name string
+		peer string
+		want pathHop
+	}{
+		{
+			name: "prefix list",
+			peer: "198.51.100.10/32",
+			want: pathHop{hop: "security group (inbound)", resource: "sg-app", pass: true, reason: "sg-app allows pl-office(198.51.100.0/24)"},
+		},
+		{
+			name: "ipv6",
+			peer: "2001:db8::10/128",
+			want: pathHop{hop: "security group (inbound)", resource: "sg-app", pass: true, reason: "sg-app allows 2001:db8::/32"},
+		},
+		{
+			name: "not evaluated",
+			peer: "203.0.113.10/32",
+			want: pathHop{hop: "security group (inbound)", resource: "sg-app", unknown: true, reason: "no rule allows tcp 443 for 203.0.113.10/32, pl-unknown not evaluated"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := evaluateSecurityGroups("security group (inbound)", &pathEndpoint{securityGroupIds: []string{"sg-app"}}, false, "tcp", 443, &pathEndpoint{cidr: tt.peer}, securityGroups, prefixLists)
+			if got != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%+v\n", tt.want)
+				t.Errorf("\ninput:\n%+v\n", got)
+			}
+		})
+	}
+}
+
+func Test_resolvePathEndpoint(t *testing.T) {
+	outputs := &pathOutputs{
+		instances: []*ec2.DescribeInstancesOutput{
+			{
+				Reservations: []types.Reservation{
+					{
+						Instances: []types.Instance{
+							{
+								InstanceId: aws.String("i-11111111"),
+								Tags: []types.Tag{
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("web01"),
+									},
+								},
+							},
+							{
+								InstanceId: aws.String("i-22222222"),
+								Tags: []types.Tag{
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("web01"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	_, err := resolvePathEndpoint("web01", outputs)
+	want := "ambiguous name web01 matches i-11111111,i-22222222, use an instance ID"
+	if err == nil || err.Error() != want {
+		t.Errorf("failed to test: ambiguous name\nwant: %s\ninput: %v\n", want, err)
+	}
+}
+
+func Test_evaluateNetworkAcl(t *testing.T) {
+	allowAll := types.NetworkAclEntry{
+		CidrBlock:  aws.String("0.0.0.0/0"),
+		Egress:     aws.Bool(false),
+		Protocol:   aws.String("-1"),
+		RuleAction: "allow",
+		RuleNumber: aws.Int32(100),
+	}
+	denyAll := types.NetworkAclEntry{
+		CidrBlock:  aws.String("0.0.0.0/0"),
+		Egress:     aws.Bool(false),
+		Protocol:   aws.String("-1"),
+		RuleAction: "deny",
+		RuleNumber: aws.Int32(32767),
+	}
+	tests := []struct {
+		name    string
+		entries []types.NetworkAclEntry
+		want    pathHop
+	}{
+		{
+			name:    "allow all",
+			entries: []types.NetworkAclEntry{allowAll, denyAll},
+			want:    pathHop{hop: "network ACL", resource: "acl-test", pass: true, reason: "rule 100 allow all 0.0.0.0/0"},
+		},
+		{
+			name: "deny part of the range before allow all",
+			entries: []types.NetworkAclEntry{
+				{
+					CidrBlock:  aws.String("0.0.0.0/0"),
+					Egress:     aws.Bool(false),
+					PortRange:  &types.PortRange{From: aws.Int32(1024), To: aws.Int32(2000)},
+					Protocol:   aws.String("6"),
+					RuleAction: "deny",
+					RuleNumber: aws.Int32(90),
+				},
+				allowAll,
+				denyAll,
+			},
+			want: pathHop{hop: "network ACL", resource: "acl-test", unknown: true, reason: "rule 100 allow all 0.0.0.0/0, but rule 90 deny tcp/1024-2000 0.0.0.0/0"},
+		},
+		{
+			name: "allow part of the range",
+			entries: []types.NetworkAclEntry{
+				{
+					CidrBlock:  aws.String("0.0.0.0/0"),
+					Egress:     aws.Bool(false),
+					PortRange:  &types.PortRange{From: aws.Int32(32768), To: aws.Int32(65535)},
+					Protocol:   aws.String("6"),
+					RuleAction: "allow",
+					RuleNumber: aws.Int32(100),
+				},
+				denyAll,
+			},
+			want: pathHop{hop: "network ACL", resource: "acl-test", unknown: true, reason: "rule * deny all 0.0.0.0/0, but rule 100 allow tcp/32768-65535 0.0.0.0/0"},
+		},
+		{
+			name:    "no entry",
+			entries: []types.NetworkAclEntry{},
+			want:    pathHop{hop: "network ACL", resource: "acl-test", reason: "no entry matches tcp 1024-65535 10.0.0.10/32"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nacl := types.NetworkAcl{
+				Entries:      tt.entries,
+				NetworkAclId: aws.String("acl-test"),
+			}
+			got := evaluateNetworkAcl("network ACL", nacl, false, "tcp", ephemeralPortFrom, ephemeralPortTo, "10.0.0.10/32")
+			if got != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%+v\n", tt.want)
+				t.Errorf("\ninput:\n%+v\n", got)
+			}
+		})
+	}
+}
+
+func Test_evaluateInternetGateway(t *testing.T) {
+	endpoint := &pathEndpoint{label: "web01", cidr: "10.0.0.10/32", public: true}
+	tests := []struct {
+		name   string
+		routes []types.Route
+		want   pathHop
+	}{
+		{
+			name: "ipv4",
+			routes: []types.Route{
+				{
+					DestinationCidrBlock: aws.String("0.0.0.0/0"),
+					GatewayId:            aws.String("igw-12345678"),
+				},
+			},
+			want: pathHop{hop: "internet gateway", resource: "rtb-test", pass: true, reason: "0.0.0.0/0 via igw-12345678"},
+		},
+		{
+			name: "ipv6 only",
+			routes: []types.Route{
+				{
+					DestinationIpv6CidrBlock: aws.String("::/0"),
+					GatewayId:                aws.String("igw-12345678"),
+				},
+				{
+					DestinationCidrBlock: aws.String("0.0.0.0/0"),
+					NatGatewayId:         aws.String("nat-12345678"),
+				},
+			},
+			want: pathHop{hop: "internet gateway", resource: "rtb-test", reason: "no 0.0.0.0/0 route to an internet gateway"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := types.RouteTable{
+				RouteTableId: aws.String("rtb-test"),
+				Routes:       tt.routes,
+			}
+			got := evaluateInternetGateway("internet gateway", rt, endpoint)
+			if got != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%+v\n", tt.want)
+				t.Errorf("\ninput:\n%+v\n", got)
+			}
+		})
+	}
+}
+
+func Test_parsePathProtocol(t *testing.T) {
+	tests := []struct {
+		flag    string
+		want    string
+		wantErr bool
+	}{
+		{flag: "tcp", want: "tcp"},
+		{flag: "UDP", want: "udp"},
+		{flag: "icmp", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			got, err := parsePathProtocol(tt.flag)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("failed to test: %s\nwant: %s\ninput: %s, %v\n", tt.flag, tt.want, got, err)
+			}
+		})
+	}
+}
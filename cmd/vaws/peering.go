@@ -0,0 +1,140 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+)
+
+const peeringMaxResult = 1000
+
+// peeringCmd represents the peering command
+var peeringCmd = &cobra.Command{
+	Use:   "peering",
+	Short: "Show VPC peering connections",
+	Long:  `Show VPC peering connections`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		cfg := newAwsConfig()
+		outputs, err := getPeeringConnections(cfg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		vpcs, err := getVpc(cfg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showPeeringConnections(outputs, vpcs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(peeringCmd)
+}
+
+func getPeeringConnections(cfg aws.Config) ([]*ec2.DescribeVpcPeeringConnectionsOutput, error) {
+	var outputs []*ec2.DescribeVpcPeeringConnectionsOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeVpcPeeringConnections API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeVpcPeeringConnections(context.TODO(), &ec2.DescribeVpcPeeringConnectionsInput{
+		MaxResults: aws.Int32(peeringMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeVpcPeeringConnections(context.TODO(), &ec2.DescribeVpcPeeringConnectionsInput{
+			MaxResults: aws.Int32(peeringMaxResult),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+func showPeeringConnections(outputs []*ec2.DescribeVpcPeeringConnectionsOutput, vpcs []*ec2.DescribeVpcsOutput, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"NAME", "ID", "REQUESTER VPC", "REQUESTER ACCOUNT", "REQUESTER REGION", "REQUESTER CIDR",
+		"ACCEPTER VPC", "ACCEPTER ACCOUNT", "ACCEPTER REGION", "ACCEPTER CIDR", "STATUS"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	vpcNames := getVpcNames(vpcs)
+	var records [][]string
+	for _, o := range outputs {
+		for _, pcx := range o.VpcPeeringConnections {
+			record := []string{getNameTag(pcx.Tags), *pcx.VpcPeeringConnectionId}
+			record = append(record, peeringVpcInfo(pcx.RequesterVpcInfo, vpcNames)...)
+			record = append(record, peeringVpcInfo(pcx.AccepterVpcInfo, vpcNames)...)
+			status := ""
+			if pcx.Status != nil {
+				status = string(pcx.Status.Code)
+			}
+			records = append(records, append(record, status))
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// peeringVpcInfo returns the VPC, account, region and CIDR blocks of one side of a peering connection.
+// The VPC name is only known when the VPC is in the current account and region.
+func peeringVpcInfo(info *types.VpcPeeringConnectionVpcInfo, vpcNames map[string]string) []string {
+	if info == nil {
+		return []string{"", "", "", ""}
+	}
+	vpc, account, region := "", "", ""
+	if info.VpcId != nil {
+		vpc = formatNameId(vpcNames[*info.VpcId], *info.VpcId)
+	}
+	if info.OwnerId != nil {
+		account = *info.OwnerId
+	}
+	if info.Region != nil {
+		region = *info.Region
+	}
+	var cidrs []string
+	for _, cidr := range info.CidrBlockSet {
+		cidrs = append(cidrs, *cidr.CidrBlock)
+	}
+	if len(cidrs) == 0 && info.CidrBlock != nil {
+		cidrs = append(cidrs, *info.CidrBlock)
+	}
+	return []string{vpc, account, region, joinValues(cidrs)}
+}
@@ -0,0 +1,100 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+func Test_showPeeringConnections(t *testing.T) {
+	type args struct {
+		outputs      []*ec2.DescribeVpcPeeringConnectionsOutput
+		vpcs         []*ec2.DescribeVpcsOutput
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs: []*ec2.DescribeVpcPeeringConnectionsOutput{
+					{
+						VpcPeeringConnections: []types.VpcPeeringConnection{
+							{
+								AccepterVpcInfo: &types.VpcPeeringConnectionVpcInfo{
+									CidrBlock: aws.String("10.1.0.0/16"),
+									CidrBlockSet: []types.CidrBlock{
+										{
+											CidrBlock: aws.String("10.1.0.0/16"),
+										},
+										{
+											CidrBlock: aws.String("10.2.0.0/16"),
+										},
+									},
+									OwnerId: aws.String("222222222222"),
+									Region:  aws.String("us-east-1"),
+									VpcId:   aws.String("vpc-22222222"),
+								},
+								RequesterVpcInfo: &types.VpcPeeringConnectionVpcInfo{
+									CidrBlock: aws.String("10.0.0.0/16"),
+									OwnerId:   aws.String("111111111111"),
+									Region:    aws.String("ap-northeast-1"),
+									VpcId:     aws.String("vpc-11111111"),
+								},
+								Status: &types.VpcPeeringConnectionStateReason{
+									Code: "active",
+								},
+								Tags: []types.Tag{
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("tokyo-virginia"),
+									},
+								},
+								VpcPeeringConnectionId: aws.String("pcx-12345678"),
+							},
+						},
+					},
+				},
+				vpcs: []*ec2.DescribeVpcsOutput{
+					{
+						Vpcs: []types.Vpc{
+							{
+								Tags: []types.Tag{
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("tokyo"),
+									},
+								},
+								VpcId: aws.String("vpc-11111111"),
+							},
+						},
+					},
+				},
+				sortPosition: 1,
+			},
+			want: `+----------------+--------------+---------------------+-------------------+------------------+----------------+--------------+------------------+-----------------+-------------------------+--------+
+|      NAME      |      ID      |    REQUESTER VPC    | REQUESTER ACCOUNT | REQUESTER REGION | REQUESTER CIDR | ACCEPTER VPC | ACCEPTER ACCOUNT | ACCEPTER REGION |      ACCEPTER CIDR      | STATUS |
++----------------+--------------+---------------------+-------------------+------------------+----------------+--------------+------------------+-----------------+-------------------------+--------+
+| tokyo-virginia | pcx-12345678 | tokyo(vpc-11111111) |      111111111111 | ap-northeast-1   | 10.0.0.0/16    | vpc-22222222 |     222222222222 | us-east-1       | 10.1.0.0/16,10.2.0.0/16 | active |
++----------------+--------------+---------------------+-------------------+------------------+----------------+--------------+------------------+-----------------+-------------------------+--------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showPeeringConnections(tt.args.outputs, tt.args.vpcs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package vaws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+)
+
+// prometheusLabelInvalidChars are the characters that Prometheus does not allow in label names.
+var prometheusLabelInvalidChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// prometheusTargetGroup is an element of the file_sd_configs JSON.
+type prometheusTargetGroup struct {
+	Targets []string          `json:"targets"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// prometheusCmd represents the inventory prometheus command
+var prometheusCmd = &cobra.Command{
+	Use:   "prometheus",
+	Short: "Generate Prometheus file_sd_configs targets",
+	Long: `Generate Prometheus file_sd_configs targets from the private IP addresses of running EC2 instances.
+Each target is labeled with its tags, instance type, AZ and account.
+With --watch the inventory is polled and the file is replaced atomically only when it changes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		port, err := cmd.Flags().GetInt("port")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if port < 1 || 65535 < port {
+			fmt.Println("--port must be between 1 and 65535")
+			os.Exit(1)
+		}
+		outputFile, err := cmd.Flags().GetString("output-file")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		watchFlg, err := cmd.Flags().GetBool("watch")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		interval, err := cmd.Flags().GetDuration("interval")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if watchFlg && outputFile == "" {
+			fmt.Println("--watch requires --output-file")
+			os.Exit(1)
+		}
+		if interval <= 0 {
+			fmt.Println("--interval must be positive")
+			os.Exit(1)
+		}
+		cfg := newAwsConfig()
+		for {
+			err := updatePrometheusTargets(cfg, port, outputFile)
+			if !watchFlg {
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+				return
+			}
+			// Keep watching so that a temporary API error does not stop the service discovery
+			if err != nil {
+				fmt.Println(err)
+			}
+			time.Sleep(interval)
+		}
+	},
+}
+
+func init() {
+	inventoryCmd.AddCommand(prometheusCmd)
+	prometheusCmd.Flags().Int("port", 9100, "Port of the targets")
+	prometheusCmd.Flags().StringP("output-file", "o", "", "File to rewrite with the targets, such as /etc/prometheus/targets/ec2.json")
+	prometheusCmd.Flags().BoolP("watch", "w", false, "Keep updating the output file")
+	prometheusCmd.Flags().Duration("interval", time.Minute, "Polling interval of --watch")
+}
+
+// updatePrometheusTargets prints the targets, or writes them to the file when outputFile is set.
+func updatePrometheusTargets(cfg aws.Config, port int, outputFile string) error {
+	outputs, err := getEc2Instances(cfg)
+	if err != nil {
+		return err
+	}
+	b, err := formatPrometheusTargets(outputs, port)
+	if err != nil {
+		return err
+	}
+	if outputFile == "" {
+		fmt.Print(string(b))
+		return nil
+	}
+	changed, err := writeFileIfChanged(outputFile, b, 0644)
+	if err != nil {
+		return err
+	}
+	if changed {
+		fmt.Printf("%s updated %s\n", time.Now().Format(time.RFC3339), outputFile)
+	}
+	return nil
+}
+
+// formatPrometheusTargets returns the file_sd_configs JSON of the instances.
+func formatPrometheusTargets(outputs []*ec2.DescribeInstancesOutput, port int) ([]byte, error) {
+	b, err := json.MarshalIndent(buildPrometheusTargets(outputs, port), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// buildPrometheusTargets returns a target group for each running instance ordered by instance ID.
+func buildPrometheusTargets(outputs []*ec2.DescribeInstancesOutput, port int) []prometheusTargetGroup {
+	groups := []prometheusTargetGroup{}
+	for _, o := range outputs {
+		for _, r := range o.Reservations {
+			for _, instance := range r.Instances {
+				if instance.State == nil || instance.State.Name != types.InstanceStateNameRunning || instance.PrivateIpAddress == nil {
+					continue
+				}
+				labels := map[string]string{
+					"account_id":    aws.ToString(r.OwnerId),
+					"instance_id":   *instance.InstanceId,
+					"instance_type": string(instance.InstanceType),
+				}
+				if instance.Placement != nil && instance.Placement.AvailabilityZone != nil {
+					labels["availability_zone"] = *instance.Placement.AvailabilityZone
+				}
+				for _, tag := range instance.Tags {
+					labels[prometheusLabelName("tag_"+*tag.Key)] = *tag.Value
+				}
+				groups = append(groups, prometheusTargetGroup{
+					Targets: []string{net.JoinHostPort(*instance.PrivateIpAddress, strconv.Itoa(port))},
+					Labels:  labels,
+				})
+			}
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Labels["instance_id"] < groups[j].Labels["instance_id"] })
+	return groups
+}
+
+// prometheusLabelName replaces the characters that are invalid in Prometheus label names with underscores.
+func prometheusLabelName(name string) string {
+	return prometheusLabelInvalidChars.ReplaceAllString(name, "_")
+}
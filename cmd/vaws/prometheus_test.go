@@ -0,0 +1,133 @@
+package vaws
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"testing"
+)
+
+func Test_formatPrometheusTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		outputs []*ec2.DescribeInstancesOutput
+		port    int
+		want    string
+	}{
+		{
+			name: "running instances",
+			outputs: []*ec2.DescribeInstancesOutput{
+				{
+					Reservations: []types.Reservation{
+						{
+							OwnerId: aws.String("111111111111"),
+							Instances: []types.Instance{
+								{
+									InstanceId:   aws.String("i-22222222"),
+									InstanceType: "t3.micro",
+									Placement: &types.Placement{
+										AvailabilityZone: aws.String("ap-northeast-1c"),
+									},
+									PrivateIpAddress: aws.String("10.0.2.10"),
+									State: &types.InstanceState{
+										Name: "running",
+									},
+									Tags: []types.Tag{
+										{
+											Key:   aws.String("Name"),
+											Value: aws.String("web02"),
+										},
+										{
+											Key:   aws.String("aws:autoscaling:groupName"),
+											Value: aws.String("web"),
+										},
+									},
+								},
+								{
+									InstanceId:       aws.String("i-33333333"),
+									InstanceType:     "t3.micro",
+									PrivateIpAddress: aws.String("10.0.2.20"),
+									State: &types.InstanceState{
+										Name: "stopped",
+									},
+								},
+							},
+						},
+						{
+							OwnerId: aws.String("111111111111"),
+							Instances: []types.Instance{
+								{
+									InstanceId:   aws.String("i-11111111"),
+									InstanceType: "m5.large",
+									Placement: &types.Placement{
+										AvailabilityZone: aws.String("ap-northeast-1a"),
+									},
+									PrivateIpAddress: aws.String("10.0.1.10"),
+									State: &types.InstanceState{
+										Name: "running",
+									},
+									Tags: []types.Tag{
+										{
+											Key:   aws.String("Name"),
+											Value: aws.String("db01"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			port: 9100,
+			want: `[
+  {
+    "targets": [
+      "10.0.1.10:9100"
+    ],
+    "labels": {
+      "account_id": "111111111111",
+      "availability_zone": "ap-northeast-1a",
+      "instance_id": "i-11111111",
+      "instance_type": "m5.large",
+      "tag_Name": "db01"
+    }
+  },
+  {
+    "targets": [
+      "10.0.2.10:9100"
+    ],
+    "labels": {
+      "account_id": "111111111111",
+      "availability_zone": "ap-northeast-1c",
+      "instance_id": "i-22222222",
+      "instance_type": "t3.micro",
+      "tag_Name": "web02",
+      "tag_aws_autoscaling_groupName": "web"
+    }
+  }
+]
+`,
+		},
+		{
+			name:    "no instance",
+			outputs: []*ec2.DescribeInstancesOutput{{}},
+			port:    9100,
+			want: `[]
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := formatPrometheusTargets(tt.outputs, tt.port)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := string(b)
+			if got != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", got)
+			}
+		})
+	}
+}
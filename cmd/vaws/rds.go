@@ -114,14 +114,11 @@ func showRdsClusters(instances *rds.DescribeDBClustersOutput, table *tablewriter
 		status := *object.Status
 		wEndpoint := *object.Endpoint
 		rEndpoint := *object.ReaderEndpoint
-		instanceId := ""
-		for i, v := range object.DBClusterMembers {
-			if i == 0 {
-				instanceId += *v.DBInstanceIdentifier
-			} else {
-				instanceId += fmt.Sprintf(", %s", *v.DBInstanceIdentifier)
-			}
+		var members []string
+		for _, v := range object.DBClusterMembers {
+			members = append(members, *v.DBInstanceIdentifier)
 		}
+		instanceId := joinValues(members)
 		records = append(records, []string{cluster, status, instanceId, wEndpoint, rEndpoint})
 	}
 	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
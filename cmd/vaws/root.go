@@ -1,6 +1,7 @@
 package vaws
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -12,6 +13,21 @@ var rootCmd = &cobra.Command{
 	Short:   "The vaws command was created to simplify the display of AWS resources.",
 	Long:    `The vaws command was created to simplify the display of AWS resources.`,
 	Version: "0.3.1",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		separator, err := cmd.Flags().GetString("separator")
+		if err != nil {
+			return err
+		}
+		switch separator {
+		case "comma":
+			valueSeparator = ","
+		case "newline":
+			valueSeparator = "\n"
+		default:
+			return fmt.Errorf("unknown separator %s, use comma or newline", separator)
+		}
+		return nil
+	},
 }
 
 func Execute() {
@@ -24,4 +40,5 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().StringP("aws-profile", "p", "", "-p my-aws")
 	rootCmd.PersistentFlags().IntP("sort-position", "s", 1, "-s 1")
+	rootCmd.PersistentFlags().String("separator", "comma", "Separator of multiple values in a cell (comma or newline)")
 }
@@ -0,0 +1,42 @@
+package vaws
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// Test_commandHelp runs --help of every command so that a flag clashing with a persistent flag of the root command panics here.
+func Test_commandHelp(t *testing.T) {
+	var walk func(cmd *cobra.Command, path []string)
+	var paths [][]string
+	walk = func(cmd *cobra.Command, path []string) {
+		paths = append(paths, path)
+		for _, c := range cmd.Commands() {
+			walk(c, append(append([]string{}, path...), c.Name()))
+		}
+	}
+	walk(rootCmd, nil)
+	for _, path := range paths {
+		name := strings.Join(append([]string{"vaws"}, path...), " ")
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			rootCmd.SetOut(&buf)
+			rootCmd.SetErr(&buf)
+			rootCmd.SetArgs(append(append([]string{}, path...), "--help"))
+			defer func() {
+				rootCmd.SetOut(nil)
+				rootCmd.SetErr(nil)
+				rootCmd.SetArgs(nil)
+				if r := recover(); r != nil {
+					t.Errorf("failed to test: %s\n%v\n", name, r)
+				}
+			}()
+			if err := rootCmd.Execute(); err != nil {
+				t.Errorf("failed to test: %s\n%v\n", name, err)
+			}
+		})
+	}
+}
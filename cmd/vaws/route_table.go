@@ -0,0 +1,168 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+)
+
+const routeTableMaxResult = 100
+
+// routeTableCmd represents the route-table command
+var routeTableCmd = &cobra.Command{
+	Use:   "route-table",
+	Short: "Show route tables",
+	Long:  `Show route tables`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		outputs, err := getRouteTables(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		err = showRouteTables(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(routeTableCmd)
+}
+
+func getRouteTables(cfg aws.Config) ([]*ec2.DescribeRouteTablesOutput, error) {
+	var outputs []*ec2.DescribeRouteTablesOutput
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	// The DescribeRouteTables API executes the API once at the beginning because the NextToken "" is disallowed
+	output, err := client.DescribeRouteTables(context.TODO(), &ec2.DescribeRouteTablesInput{
+		MaxResults: aws.Int32(routeTableMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, output)
+	for output.NextToken != nil {
+		output, err = client.DescribeRouteTables(context.TODO(), &ec2.DescribeRouteTablesInput{
+			MaxResults: aws.Int32(routeTableMaxResult),
+			NextToken:  output.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+func showRouteTables(outputs []*ec2.DescribeRouteTablesOutput, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"NAME", "ID", "VPC", "ASSOCIATION", "DESTINATION", "TARGET", "STATE"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	var records [][]string
+	for _, o := range outputs {
+		for _, rt := range o.RouteTables {
+			name := getNameTag(rt.Tags)
+			association := routeTableAssociation(rt)
+			for _, route := range rt.Routes {
+				records = append(records, []string{
+					name,
+					*rt.RouteTableId,
+					*rt.VpcId,
+					association,
+					routeDestination(route),
+					routeTarget(route),
+					string(route.State),
+				})
+			}
+		}
+	}
+	// Keep the order of routes within the same route table
+	sort.SliceStable(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// routeTableAssociation returns "main" and the associated subnet IDs of the route table.
+func routeTableAssociation(rt types.RouteTable) string {
+	var associations []string
+	for _, a := range rt.Associations {
+		if a.Main != nil && *a.Main {
+			associations = append(associations, "main")
+		}
+		if a.SubnetId != nil {
+			associations = append(associations, *a.SubnetId)
+		}
+		if a.GatewayId != nil {
+			associations = append(associations, *a.GatewayId)
+		}
+	}
+	return joinValues(associations)
+}
+
+func routeDestination(route types.Route) string {
+	switch {
+	case route.DestinationCidrBlock != nil:
+		return *route.DestinationCidrBlock
+	case route.DestinationIpv6CidrBlock != nil:
+		return *route.DestinationIpv6CidrBlock
+	case route.DestinationPrefixListId != nil:
+		return *route.DestinationPrefixListId
+	}
+	return ""
+}
+
+// routeTarget returns the ID of the route target, such as "local", igw-xxx, nat-xxx, tgw-xxx, pcx-xxx, vpce-xxx or eni-xxx.
+func routeTarget(route types.Route) string {
+	switch {
+	case route.GatewayId != nil:
+		return *route.GatewayId
+	case route.NatGatewayId != nil:
+		return *route.NatGatewayId
+	case route.TransitGatewayId != nil:
+		return *route.TransitGatewayId
+	case route.VpcPeeringConnectionId != nil:
+		return *route.VpcPeeringConnectionId
+	case route.EgressOnlyInternetGatewayId != nil:
+		return *route.EgressOnlyInternetGatewayId
+	case route.InstanceId != nil:
+		return *route.InstanceId
+	case route.NetworkInterfaceId != nil:
+		return *route.NetworkInterfaceId
+	case route.CarrierGatewayId != nil:
+		return *route.CarrierGatewayId
+	case route.LocalGatewayId != nil:
+		return *route.LocalGatewayId
+	case route.CoreNetworkArn != nil:
+		return *route.CoreNetworkArn
+	}
+	return ""
+}
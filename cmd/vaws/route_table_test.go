@@ -0,0 +1,128 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+func Test_showRouteTables(t *testing.T) {
+	type args struct {
+		outputs      []*ec2.DescribeRouteTablesOutput
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs: []*ec2.DescribeRouteTablesOutput{
+					{
+						RouteTables: []types.RouteTable{
+							{
+								Associations: []types.RouteTableAssociation{
+									{
+										SubnetId: aws.String("subnet-xxxxxxxx"),
+									},
+									{
+										SubnetId: aws.String("subnet-yyyyyyyy"),
+									},
+								},
+								RouteTableId: aws.String("rtb-22222222"),
+								Routes: []types.Route{
+									{
+										DestinationCidrBlock: aws.String("10.0.0.0/16"),
+										GatewayId:            aws.String("local"),
+										State:                "active",
+									},
+									{
+										DestinationCidrBlock: aws.String("0.0.0.0/0"),
+										GatewayId:            aws.String("igw-12345678"),
+										State:                "active",
+									},
+									{
+										DestinationPrefixListId: aws.String("pl-61a54008"),
+										GatewayId:               aws.String("vpce-12345678"),
+										State:                   "active",
+									},
+								},
+								Tags: []types.Tag{
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("public"),
+									},
+								},
+								VpcId: aws.String("vpc-12345678"),
+							},
+						},
+					},
+					{
+						RouteTables: []types.RouteTable{
+							{
+								Associations: []types.RouteTableAssociation{
+									{
+										Main: aws.Bool(true),
+									},
+								},
+								RouteTableId: aws.String("rtb-11111111"),
+								Routes: []types.Route{
+									{
+										DestinationCidrBlock: aws.String("10.0.0.0/16"),
+										GatewayId:            aws.String("local"),
+										State:                "active",
+									},
+									{
+										DestinationCidrBlock: aws.String("0.0.0.0/0"),
+										NatGatewayId:         aws.String("nat-12345678"),
+										State:                "blackhole",
+									},
+									{
+										DestinationCidrBlock:   aws.String("10.1.0.0/16"),
+										VpcPeeringConnectionId: aws.String("pcx-12345678"),
+										State:                  "active",
+									},
+								},
+								Tags: []types.Tag{
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("private"),
+									},
+								},
+								VpcId: aws.String("vpc-12345678"),
+							},
+						},
+					},
+				},
+				sortPosition: 2,
+			},
+			want: `+---------+--------------+--------------+---------------------------------+-------------+---------------+-----------+
+|  NAME   |      ID      |     VPC      |           ASSOCIATION           | DESTINATION |    TARGET     |   STATE   |
++---------+--------------+--------------+---------------------------------+-------------+---------------+-----------+
+| private | rtb-11111111 | vpc-12345678 | main                            | 10.0.0.0/16 | local         | active    |
+| private | rtb-11111111 | vpc-12345678 | main                            | 0.0.0.0/0   | nat-12345678  | blackhole |
+| private | rtb-11111111 | vpc-12345678 | main                            | 10.1.0.0/16 | pcx-12345678  | active    |
+| public  | rtb-22222222 | vpc-12345678 | subnet-xxxxxxxx,subnet-yyyyyyyy | 10.0.0.0/16 | local         | active    |
+| public  | rtb-22222222 | vpc-12345678 | subnet-xxxxxxxx,subnet-yyyyyyyy | 0.0.0.0/0   | igw-12345678  | active    |
+| public  | rtb-22222222 | vpc-12345678 | subnet-xxxxxxxx,subnet-yyyyyyyy | pl-61a54008 | vpce-12345678 | active    |
++---------+--------------+--------------+---------------------------------+-------------+---------------+-----------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showRouteTables(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
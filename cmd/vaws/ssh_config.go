@@ -0,0 +1,363 @@
+package vaws
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/spf13/cobra"
+)
+
+// sshConfigHeader marks the file as generated so that it is rewritten as a whole.
+const sshConfigHeader = "# Managed by vaws ssh-config. Manual changes are overwritten.\n"
+
+// sshConfigOptions decides the address, user, identity file and jump host of each Host block.
+type sshConfigOptions struct {
+	public       bool
+	user         string
+	identityFile string
+	rules        []sshConfigRule
+	bastionKey   string
+	bastionValue string
+}
+
+// sshConfigRule sets User and IdentityFile for instances with the tag. The first matching rule wins.
+type sshConfigRule struct {
+	tagKey       string
+	tagValue     string
+	user         string
+	identityFile string
+}
+
+// sshConfigCmd represents the ssh-config command
+var sshConfigCmd = &cobra.Command{
+	Use:   "ssh-config",
+	Short: "Generate SSH config entries for running EC2 instances",
+	Long: `Generate SSH config entries for running EC2 instances.
+The Host of each entry is the Name tag, and a suffix such as -2 is added to duplicate names.
+With --output-file the entries are written to a file that can be included from ~/.ssh/config.
+User and IdentityFile come from the --user, --identity-file and --rule flags only; there is no config file for them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		options, err := getSshConfigOptions(cmd)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		outputFile, err := cmd.Flags().GetString("output-file")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		outputs, err := getEc2Instances(newAwsConfig())
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		config := buildSshConfig(outputs, options)
+		if outputFile == "" {
+			fmt.Print(config)
+			return
+		}
+		changed, err := writeSshConfigFile(outputFile, config)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if changed {
+			fmt.Printf("updated %s\n", outputFile)
+		} else {
+			fmt.Printf("%s is up to date\n", outputFile)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(sshConfigCmd)
+	sshConfigCmd.Flags().Bool("public", false, "Use public IP addresses instead of private ones")
+	sshConfigCmd.Flags().StringP("user", "u", "", "Default User")
+	sshConfigCmd.Flags().StringP("identity-file", "i", "", "Default IdentityFile")
+	sshConfigCmd.Flags().StringArrayP("rule", "r", nil, "User and IdentityFile for a tag such as OS=ubuntu:user=ubuntu,identity-file=~/.ssh/ubuntu.pem")
+	sshConfigCmd.Flags().StringP("bastion", "b", "", "Tag of the bastion to use as ProxyJump such as Role=bastion")
+	sshConfigCmd.Flags().StringP("output-file", "o", "", "File to rewrite with the entries, such as ~/.ssh/config.d/vaws")
+}
+
+func getSshConfigOptions(cmd *cobra.Command) (sshConfigOptions, error) {
+	var options sshConfigOptions
+	var err error
+	options.public, err = cmd.Flags().GetBool("public")
+	if err != nil {
+		return options, err
+	}
+	options.user, err = cmd.Flags().GetString("user")
+	if err != nil {
+		return options, err
+	}
+	options.identityFile, err = cmd.Flags().GetString("identity-file")
+	if err != nil {
+		return options, err
+	}
+	ruleFlgs, err := cmd.Flags().GetStringArray("rule")
+	if err != nil {
+		return options, err
+	}
+	for _, flag := range ruleFlgs {
+		rule, err := parseSshConfigRule(flag)
+		if err != nil {
+			return options, err
+		}
+		options.rules = append(options.rules, rule)
+	}
+	bastion, err := cmd.Flags().GetString("bastion")
+	if err != nil {
+		return options, err
+	}
+	if bastion != "" {
+		options.bastionKey, options.bastionValue, err = parseTag(bastion)
+		if err != nil {
+			return options, err
+		}
+	}
+	return options, nil
+}
+
+// parseSshConfigRule parses "key=value:user=name,identity-file=path".
+func parseSshConfigRule(flag string) (sshConfigRule, error) {
+	var rule sshConfigRule
+	i := strings.Index(flag, ":")
+	if i < 0 {
+		return rule, fmt.Errorf("invalid rule %s, use key=value:user=name,identity-file=path", flag)
+	}
+	var err error
+	rule.tagKey, rule.tagValue, err = parseTag(flag[:i])
+	if err != nil {
+		return rule, err
+	}
+	for _, setting := range strings.Split(flag[i+1:], ",") {
+		name, value, err := parseTag(setting)
+		if err != nil {
+			return rule, err
+		}
+		switch name {
+		case "user":
+			rule.user = value
+		case "identity-file":
+			rule.identityFile = value
+		default:
+			return rule, fmt.Errorf("unknown setting %s in rule %s, use user or identity-file", name, flag)
+		}
+	}
+	return rule, nil
+}
+
+// parseTag splits "key=value".
+func parseTag(s string) (string, string, error) {
+	i := strings.Index(s, "=")
+	if i < 1 || i == len(s)-1 {
+		return "", "", fmt.Errorf("invalid %s, use key=value", s)
+	}
+	return s[:i], s[i+1:], nil
+}
+
+// buildSshConfig returns a Host block for each running instance ordered by host name.
+func buildSshConfig(outputs []*ec2.DescribeInstancesOutput, options sshConfigOptions) string {
+	instances := getRunningInstances(outputs)
+	hosts := sshConfigHosts(instances)
+	bastion := ""
+	if options.bastionKey != "" {
+		for _, instance := range instances {
+			if hasTag(instance.Tags, options.bastionKey, options.bastionValue) {
+				bastion = hosts[*instance.InstanceId]
+				break
+			}
+		}
+	}
+	blocks := map[string]string{}
+	for _, instance := range instances {
+		host := hosts[*instance.InstanceId]
+		isBastion := host == bastion
+		address := aws.ToString(instance.PrivateIpAddress)
+		if options.public || isBastion {
+			address = aws.ToString(instance.PublicIpAddress)
+		}
+		if isBastion && address == "" {
+			address = aws.ToString(instance.PrivateIpAddress)
+		}
+		if address == "" {
+			continue
+		}
+		user, identityFile := options.user, options.identityFile
+		for _, rule := range options.rules {
+			if hasTag(instance.Tags, rule.tagKey, rule.tagValue) {
+				if rule.user != "" {
+					user = rule.user
+				}
+				if rule.identityFile != "" {
+					identityFile = rule.identityFile
+				}
+				break
+			}
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s\n", *instance.InstanceId)
+		fmt.Fprintf(&b, "Host %s\n", host)
+		fmt.Fprintf(&b, "  HostName %s\n", address)
+		if user != "" {
+			fmt.Fprintf(&b, "  User %s\n", user)
+		}
+		if identityFile != "" {
+			fmt.Fprintf(&b, "  IdentityFile %s\n", identityFile)
+		}
+		if bastion != "" && !isBastion && !options.public {
+			fmt.Fprintf(&b, "  ProxyJump %s\n", bastion)
+		}
+		blocks[host] = b.String()
+	}
+	var names []string
+	for host := range blocks {
+		names = append(names, host)
+	}
+	sort.Strings(names)
+	var config strings.Builder
+	config.WriteString(sshConfigHeader)
+	for _, host := range names {
+		config.WriteString("\n")
+		config.WriteString(blocks[host])
+	}
+	return config.String()
+}
+
+// getRunningInstances returns the running instances ordered by launch time, then by instance ID.
+func getRunningInstances(outputs []*ec2.DescribeInstancesOutput) []types.Instance {
+	var instances []types.Instance
+	for _, o := range outputs {
+		for _, r := range o.Reservations {
+			for _, instance := range r.Instances {
+				if instance.State != nil && instance.State.Name == types.InstanceStateNameRunning {
+					instances = append(instances, instance)
+				}
+			}
+		}
+	}
+	sort.SliceStable(instances, func(i, j int) bool {
+		if instances[i].LaunchTime != nil && instances[j].LaunchTime != nil && !instances[i].LaunchTime.Equal(*instances[j].LaunchTime) {
+			return instances[i].LaunchTime.Before(*instances[j].LaunchTime)
+		}
+		return *instances[i].InstanceId < *instances[j].InstanceId
+	})
+	return instances
+}
+
+// sshConfigHosts returns a unique host name for each instance ID.
+// The instances must be in the order that decides which one gets the name without a suffix,
+// so that older instances from getRunningInstances keep the name.
+func sshConfigHosts(instances []types.Instance) map[string]string {
+	hosts := map[string]string{}
+	used := map[string]bool{}
+	var bases []string
+	for _, instance := range instances {
+		base := strings.Join(strings.FieldsFunc(getNameTag(instance.Tags), isSshConfigHostSeparator), "-")
+		if base == "" {
+			base = *instance.InstanceId
+		}
+		bases = append(bases, base)
+		used[base] = false
+	}
+	// Names without a suffix are taken first so that a suffixed name never steals an existing one
+	suffixes := map[string]int{}
+	for i, instance := range instances {
+		if !used[bases[i]] {
+			used[bases[i]] = true
+			hosts[*instance.InstanceId] = bases[i]
+		}
+	}
+	for i, instance := range instances {
+		if _, ok := hosts[*instance.InstanceId]; ok {
+			continue
+		}
+		for {
+			suffixes[bases[i]]++
+			host := fmt.Sprintf("%s-%d", bases[i], suffixes[bases[i]]+1)
+			if _, ok := used[host]; !ok {
+				used[host] = true
+				hosts[*instance.InstanceId] = host
+				break
+			}
+		}
+	}
+	return hosts
+}
+
+// isSshConfigHostSeparator reports whether the character cannot be in a Host name.
+// Whitespace separates patterns, *, ? and ! make wildcard and negated patterns and # starts a comment.
+func isSshConfigHostSeparator(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune("*?!#", r)
+}
+
+func hasTag(tags []types.Tag, key string, value string) bool {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key && aws.ToString(tag.Value) == value {
+			return true
+		}
+	}
+	return false
+}
+
+// writeSshConfigFile rewrites the file unless it exists without sshConfigHeader, and reports whether it changed.
+func writeSshConfigFile(path string, config string) (bool, error) {
+	current, err := os.ReadFile(path)
+	if err == nil && !bytes.HasPrefix(current, []byte(sshConfigHeader)) {
+		return false, fmt.Errorf("%s exists and is not managed by vaws", path)
+	}
+	return writeFileIfChanged(path, []byte(config), 0600)
+}
+
+// writeFileIfChanged replaces the file only when the content differs, and reports whether it did.
+// The file is replaced by renaming a temporary file so that readers never see a partial file.
+func writeFileIfChanged(path string, content []byte, perm os.FileMode) (bool, error) {
+	current, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(current, content) {
+		return false, nil
+	}
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return false, err
+	}
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return false, err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return false, err
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
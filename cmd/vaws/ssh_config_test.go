@@ -0,0 +1,220 @@
+package vaws
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testSshConfigInstances = []*ec2.DescribeInstancesOutput{
+	{
+		Reservations: []types.Reservation{
+			{
+				Instances: []types.Instance{
+					{
+						InstanceId:       aws.String("i-22222222"),
+						LaunchTime:       aws.Time(time.Date(2022, 2, 2, 0, 0, 0, 0, time.UTC)),
+						PrivateIpAddress: aws.String("10.0.1.11"),
+						State: &types.InstanceState{
+							Name: "running",
+						},
+						Tags: []types.Tag{
+							{
+								Key:   aws.String("Name"),
+								Value: aws.String("web01"),
+							},
+						},
+					},
+					{
+						InstanceId:       aws.String("i-11111111"),
+						LaunchTime:       aws.Time(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)),
+						PrivateIpAddress: aws.String("10.0.1.10"),
+						State: &types.InstanceState{
+							Name: "running",
+						},
+						Tags: []types.Tag{
+							{
+								Key:   aws.String("Name"),
+								Value: aws.String("web01"),
+							},
+						},
+					},
+					{
+						InstanceId:       aws.String("i-33333333"),
+						PrivateIpAddress: aws.String("10.0.1.20"),
+						State: &types.InstanceState{
+							Name: "running",
+						},
+						Tags: []types.Tag{
+							{
+								Key:   aws.String("Name"),
+								Value: aws.String("web01-2"),
+							},
+							{
+								Key:   aws.String("OS"),
+								Value: aws.String("ubuntu"),
+							},
+						},
+					},
+					{
+						InstanceId:       aws.String("i-44444444"),
+						PrivateIpAddress: aws.String("10.0.0.5"),
+						PublicIpAddress:  aws.String("203.0.113.5"),
+						State: &types.InstanceState{
+							Name: "running",
+						},
+						Tags: []types.Tag{
+							{
+								Key:   aws.String("Name"),
+								Value: aws.String("bastion"),
+							},
+							{
+								Key:   aws.String("Role"),
+								Value: aws.String("bastion"),
+							},
+						},
+					},
+					{
+						InstanceId:       aws.String("i-55555555"),
+						PrivateIpAddress: aws.String("10.0.1.30"),
+						State: &types.InstanceState{
+							Name: "stopped",
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func Test_buildSshConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		options sshConfigOptions
+		want    string
+	}{
+		{
+			name: "bastion and rules",
+			options: sshConfigOptions{
+				user:         "ec2-user",
+				identityFile: "~/.ssh/default.pem",
+				rules: []sshConfigRule{
+					{
+						tagKey:   "OS",
+						tagValue: "ubuntu",
+						user:     "ubuntu",
+					},
+				},
+				bastionKey:   "Role",
+				bastionValue: "bastion",
+			},
+			want: `# Managed by vaws ssh-config. Manual changes are overwritten.
+
+# i-44444444
+Host bastion
+  HostName 203.0.113.5
+  User ec2-user
+  IdentityFile ~/.ssh/default.pem
+
+# i-11111111
+Host web01
+  HostName 10.0.1.10
+  User ec2-user
+  IdentityFile ~/.ssh/default.pem
+  ProxyJump bastion
+
+# i-33333333
+Host web01-2
+  HostName 10.0.1.20
+  User ubuntu
+  IdentityFile ~/.ssh/default.pem
+  ProxyJump bastion
+
+# i-22222222
+Host web01-3
+  HostName 10.0.1.11
+  User ec2-user
+  IdentityFile ~/.ssh/default.pem
+  ProxyJump bastion
+`,
+		},
+		{
+			name: "public",
+			options: sshConfigOptions{
+				public: true,
+			},
+			want: `# Managed by vaws ssh-config. Manual changes are overwritten.
+
+# i-44444444
+Host bastion
+  HostName 203.0.113.5
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildSshConfig(testSshConfigInstances, tt.options)
+			if got != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", got)
+			}
+		})
+	}
+}
+
+func Test_writeSshConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.d", "vaws")
+	config := sshConfigHeader + "\nHost web01\n  HostName 10.0.1.10\n"
+	for i, want := range []bool{true, false} {
+		changed, err := writeSshConfigFile(path, config)
+		if err != nil || changed != want {
+			t.Errorf("failed to test: write %d\nwant: %v\ninput: %v, %v\n", i+1, want, changed, err)
+		}
+	}
+	if b, err := os.ReadFile(path); err != nil || string(b) != config {
+		t.Errorf("failed to test: content\nwant:\n%s\ninput:\n%s\n", config, b)
+	}
+	unmanaged := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(unmanaged, []byte("Host *\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := writeSshConfigFile(unmanaged, config); err == nil {
+		t.Errorf("failed to test: unmanaged file was overwritten\n")
+	}
+}
+
+func Test_sshConfigHosts(t *testing.T) {
+	instances := []types.Instance{
+		{
+			InstanceId: aws.String("i-11111111"),
+			Tags: []types.Tag{
+				{
+					Key:   aws.String("Name"),
+					Value: aws.String("web* #1"),
+				},
+			},
+		},
+		{
+			InstanceId: aws.String("i-22222222"),
+			Tags: []types.Tag{
+				{
+					Key:   aws.String("Name"),
+					Value: aws.String("!db?"),
+				},
+			},
+		},
+	}
+	want := map[string]string{
+		"i-11111111": "web-1",
+		"i-22222222": "db",
+	}
+	if got := sshConfigHosts(instances); !reflect.DeepEqual(got, want) {
+		t.Errorf("failed to test: special characters\nwant: %v\ninput: %v\n", want, got)
+	}
+}
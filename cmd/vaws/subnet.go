@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/olekukonko/tablewriter"
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -31,22 +33,50 @@ var subnetCmd = &cobra.Command{
 				os.Exit(1)
 			}
 		}
-		outputs, err := getSubnets(newAwsConfig())
+		cfg := newAwsConfig()
+		outputs, err := getSubnets(cfg)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+		routeTables, err := getRouteTables(cfg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		azBalanceFlg, err := cmd.Flags().GetBool("az-balance")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if azBalanceFlg {
+			showSubnetAzBalance(outputs, routeTables, tablewriter.NewWriter(os.Stdout))
+			return
+		}
 		sortPosition, err := cmd.Flags().GetInt("sort-position")
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
-		showSubnets(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		err = showSubnets(outputs, routeTables, tablewriter.NewWriter(os.Stdout), sortPosition)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 	},
 }
 
+// Subnet tiers derived from the default route of the associated route table.
+const (
+	subnetTierPublic         = "public"
+	subnetTierPrivateWithNat = "private-with-NAT"
+	subnetTierPrivateOther   = "private-other"
+	subnetTierIsolated       = "isolated"
+)
+
 func init() {
 	rootCmd.AddCommand(subnetCmd)
+	subnetCmd.Flags().BoolP("az-balance", "a", false, "Show subnets of each tier per AZ")
 }
 
 func getSubnets(cfg aws.Config) ([]*ec2.DescribeSubnetsOutput, error) {
@@ -71,14 +101,15 @@ func getSubnets(cfg aws.Config) ([]*ec2.DescribeSubnetsOutput, error) {
 	return outputs, nil
 }
 
-func showSubnets(outputs []*ec2.DescribeSubnetsOutput, table *tablewriter.Table, sortPosition int) error {
-	header := []string{"NAME", "SUBNET ID", "CIDR", "VPC", "AZ", "AZ ID", "MAP PUBLIC IP", "AVAILABLE IP COUNT"}
+func showSubnets(outputs []*ec2.DescribeSubnetsOutput, routeTables []*ec2.DescribeRouteTablesOutput, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"NAME", "SUBNET ID", "CIDR", "VPC", "AZ", "AZ ID", "TIER", "MAP PUBLIC IP", "AVAILABLE IP COUNT"}
 	if sortPosition > len(header) || 1 > sortPosition {
 		return fmt.Errorf("out of sort range number when using --sort option")
 	}
 	recordIndex := sortPosition - 1
 	table.SetHeader(header)
 	var records [][]string
+	tiers := getSubnetTiers(outputs, routeTables)
 	for _, o := range outputs {
 		for _, subnet := range o.Subnets {
 			name := ""
@@ -92,9 +123,10 @@ func showSubnets(outputs []*ec2.DescribeSubnetsOutput, table *tablewriter.Table,
 			vpc := *subnet.VpcId
 			az := *subnet.AvailabilityZone
 			azId := *subnet.AvailabilityZoneId
+			tier := tiers[id]
 			isPublicIp := strconv.FormatBool(*subnet.MapPublicIpOnLaunch)
 			count := fmt.Sprintf("%d", *subnet.AvailableIpAddressCount)
-			records = append(records, []string{name, id, cidr, vpc, az, azId, isPublicIp, count})
+			records = append(records, []string{name, id, cidr, vpc, az, azId, tier, isPublicIp, count})
 		}
 	}
 	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
@@ -102,3 +134,145 @@ func showSubnets(outputs []*ec2.DescribeSubnetsOutput, table *tablewriter.Table,
 	table.Render()
 	return nil
 }
+
+// showSubnetAzBalance shows the subnets of each tier per AZ so that a tier missing in some AZ stands out.
+func showSubnetAzBalance(outputs []*ec2.DescribeSubnetsOutput, routeTables []*ec2.DescribeRouteTablesOutput, table *tablewriter.Table) {
+	tiers := getSubnetTiers(outputs, routeTables)
+	azSet := map[string]bool{}
+	vpcSet := map[string]bool{}
+	// vpc -> tier -> az -> subnets
+	balance := map[string]map[string]map[string][]string{}
+	for _, o := range outputs {
+		for _, subnet := range o.Subnets {
+			vpc := *subnet.VpcId
+			az := *subnet.AvailabilityZone
+			tier := tiers[*subnet.SubnetId]
+			azSet[az] = true
+			vpcSet[vpc] = true
+			if balance[vpc] == nil {
+				balance[vpc] = map[string]map[string][]string{}
+			}
+			if balance[vpc][tier] == nil {
+				balance[vpc][tier] = map[string][]string{}
+			}
+			name := getNameTag(subnet.Tags)
+			if name == "" {
+				name = *subnet.SubnetId
+			}
+			balance[vpc][tier][az] = append(balance[vpc][tier][az], name)
+		}
+	}
+	var azs, vpcs []string
+	for az := range azSet {
+		azs = append(azs, az)
+	}
+	for vpc := range vpcSet {
+		vpcs = append(vpcs, vpc)
+	}
+	sort.Strings(azs)
+	sort.Strings(vpcs)
+	table.SetHeader(append([]string{"VPC", "TIER"}, azs...))
+	var records [][]string
+	for _, vpc := range vpcs {
+		for _, tier := range []string{subnetTierPublic, subnetTierPrivateWithNat, subnetTierPrivateOther, subnetTierIsolated} {
+			if balance[vpc][tier] == nil {
+				continue
+			}
+			record := []string{vpc, tier}
+			for _, az := range azs {
+				subnets := balance[vpc][tier][az]
+				sort.Strings(subnets)
+				record = append(record, joinValues(subnets))
+			}
+			records = append(records, record)
+		}
+	}
+	table.AppendBulk(records)
+	table.Render()
+}
+
+// getSubnetTiers classifies each subnet by the default route of its route table.
+func getSubnetTiers(outputs []*ec2.DescribeSubnetsOutput, routeTables []*ec2.DescribeRouteTablesOutput) map[string]string {
+	subnetRouteTables := getSubnetRouteTables(outputs, routeTables)
+	tiers := map[string]string{}
+	for _, o := range outputs {
+		for _, subnet := range o.Subnets {
+			rt, ok := subnetRouteTables[*subnet.SubnetId]
+			if !ok {
+				tiers[*subnet.SubnetId] = subnetTierIsolated
+				continue
+			}
+			tiers[*subnet.SubnetId] = routeTableTier(rt)
+		}
+	}
+	return tiers
+}
+
+// getSubnetRouteTables returns the route table of each subnet ID.
+// Subnets without an explicit association use the main route table of the VPC.
+func getSubnetRouteTables(outputs []*ec2.DescribeSubnetsOutput, routeTables []*ec2.DescribeRouteTablesOutput) map[string]types.RouteTable {
+	explicit := map[string]types.RouteTable{}
+	main := map[string]types.RouteTable{}
+	for _, o := range routeTables {
+		for _, rt := range o.RouteTables {
+			for _, a := range rt.Associations {
+				if a.Main != nil && *a.Main {
+					main[*rt.VpcId] = rt
+				}
+				if a.SubnetId != nil {
+					explicit[*a.SubnetId] = rt
+				}
+			}
+		}
+	}
+	subnetRouteTables := map[string]types.RouteTable{}
+	for _, o := range outputs {
+		for _, subnet := range o.Subnets {
+			rt, ok := explicit[*subnet.SubnetId]
+			if !ok {
+				rt, ok = main[*subnet.VpcId]
+			}
+			if ok {
+				subnetRouteTables[*subnet.SubnetId] = rt
+			}
+		}
+	}
+	return subnetRouteTables
+}
+
+// routeTableTier returns "public" if the default route goes to an internet gateway,
+// "private-with-NAT" if it goes to a NAT gateway or NAT instance,
+// "private-other" if it goes anywhere else such as a transit gateway or network interface and "isolated" otherwise.
+func routeTableTier(rt types.RouteTable) string {
+	tier := subnetTierIsolated
+	for _, route := range rt.Routes {
+		if route.State == types.RouteStateBlackhole {
+			continue
+		}
+		destination := routeDestination(route)
+		if destination != "0.0.0.0/0" && destination != "::/0" {
+			continue
+		}
+		switch {
+		case route.GatewayId != nil && strings.HasPrefix(*route.GatewayId, "igw-"):
+			return subnetTierPublic
+		// A route to a NAT instance has InstanceId besides the NetworkInterfaceId of the instance
+		case route.NatGatewayId != nil, route.InstanceId != nil:
+			tier = subnetTierPrivateWithNat
+		case tier == subnetTierIsolated:
+			tier = subnetTierPrivateOther
+		}
+	}
+	return tier
+}
+
+// getSubnetNames returns the Name tag of each subnet ID.
+func getSubnetNames(outputs []*ec2.DescribeSubnetsOutput) map[string]string {
+	names := map[string]string{}
+	for _, o := range outputs {
+		for _, subnet := range o.Subnets {
+			names[*subnet.SubnetId] = getNameTag(subnet.Tags)
+		}
+	}
+	return names
+}
@@ -9,9 +9,80 @@ import (
 	"testing"
 )
 
+var testSubnetRouteTables = []*ec2.DescribeRouteTablesOutput{
+	{
+		RouteTables: []types.RouteTable{
+			{
+				Associations: []types.RouteTableAssociation{
+					{
+						Main: aws.Bool(true),
+					},
+				},
+				RouteTableId: aws.String("rtb-11111111"),
+				Routes: []types.Route{
+					{
+						DestinationCidrBlock: aws.String("10.0.0.0/8"),
+						GatewayId:            aws.String("local"),
+						State:                "active",
+					},
+					{
+						DestinationCidrBlock: aws.String("0.0.0.0/0"),
+						NatGatewayId:         aws.String("nat-12345678"),
+						State:                "active",
+					},
+				},
+				VpcId: aws.String("vpc-12345678"),
+			},
+			{
+				Associations: []types.RouteTableAssociation{
+					{
+						SubnetId: aws.String("subnet-xxxxxxxx"),
+					},
+				},
+				RouteTableId: aws.String("rtb-22222222"),
+				Routes: []types.Route{
+					{
+						DestinationCidrBlock: aws.String("10.0.0.0/8"),
+						GatewayId:            aws.String("local"),
+						State:                "active",
+					},
+					{
+						DestinationCidrBlock: aws.String("0.0.0.0/0"),
+						GatewayId:            aws.String("igw-12345678"),
+						State:                "active",
+					},
+				},
+				VpcId: aws.String("vpc-12345678"),
+			},
+			{
+				Associations: []types.RouteTableAssociation{
+					{
+						SubnetId: aws.String("subnet-zzzzzzzz"),
+					},
+				},
+				RouteTableId: aws.String("rtb-33333333"),
+				Routes: []types.Route{
+					{
+						DestinationCidrBlock: aws.String("10.0.0.0/8"),
+						GatewayId:            aws.String("local"),
+						State:                "active",
+					},
+					{
+						DestinationCidrBlock: aws.String("0.0.0.0/0"),
+						NatGatewayId:         aws.String("nat-87654321"),
+						State:                "blackhole",
+					},
+				},
+				VpcId: aws.String("vpc-12345678"),
+			},
+		},
+	},
+}
+
 func Test_showSubnets(t *testing.T) {
 	type args struct {
 		outputs      []*ec2.DescribeSubnetsOutput
+		routeTables  []*ec2.DescribeRouteTablesOutput
 		sortPosition int
 	}
 	tests := []struct {
@@ -77,22 +148,93 @@ func Test_showSubnets(t *testing.T) {
 						},
 					},
 				},
+				routeTables:  testSubnetRouteTables,
 				sortPosition: 1,
 			},
-			want: `+----------------+-----------------+-------------+--------------+-----------------+-----------+---------------+--------------------+
-|      NAME      |    SUBNET ID    |    CIDR     |     VPC      |       AZ        |   AZ ID   | MAP PUBLIC IP | AVAILABLE IP COUNT |
-+----------------+-----------------+-------------+--------------+-----------------+-----------+---------------+--------------------+
-| test-subnet-01 | subnet-yyyyyyyy | 10.1.0.0/24 | vpc-12345678 | ap-northeast-1a | apne1-az4 | false         |                250 |
-| test-subnet-02 | subnet-xxxxxxxx | 10.2.0.0/24 | vpc-12345678 | ap-northeast-1a | apne1-az4 | true          |                250 |
-| test-subnet-03 | subnet-zzzzzzzz | 10.3.0.0/24 | vpc-12345678 | ap-northeast-1a | apne1-az4 | false         |                250 |
-+----------------+-----------------+-------------+--------------+-----------------+-----------+---------------+--------------------+
+			want: `+----------------+-----------------+-------------+--------------+-----------------+-----------+------------------+---------------+--------------------+
+|      NAME      |    SUBNET ID    |    CIDR     |     VPC      |       AZ        |   AZ ID   |       TIER       | MAP PUBLIC IP | AVAILABLE IP COUNT |
++----------------+-----------------+-------------+--------------+-----------------+-----------+------------------+---------------+--------------------+
+| test-subnet-01 | subnet-yyyyyyyy | 10.1.0.0/24 | vpc-12345678 | ap-northeast-1a | apne1-az4 | private-with-NAT | false         |                250 |
+| test-subnet-02 | subnet-xxxxxxxx | 10.2.0.0/24 | vpc-12345678 | ap-northeast-1a | apne1-az4 | public           | true          |                250 |
+| test-subnet-03 | subnet-zzzzzzzz | 10.3.0.0/24 | vpc-12345678 | ap-northeast-1a | apne1-az4 | isolated         | false         |                250 |
++----------------+-----------------+-------------+--------------+-----------------+-----------+------------------+---------------+--------------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showSubnets(tt.args.outputs, tt.args.routeTables, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
+
+func Test_showSubnetAzBalance(t *testing.T) {
+	type args struct {
+		outputs     []*ec2.DescribeSubnetsOutput
+		routeTables []*ec2.DescribeRouteTablesOutput
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs: []*ec2.DescribeSubnetsOutput{
+					{
+						Subnets: []types.Subnet{
+							{
+								AvailabilityZone: aws.String("ap-northeast-1a"),
+								SubnetId:         aws.String("subnet-xxxxxxxx"),
+								Tags: []types.Tag{
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("public-a"),
+									},
+								},
+								VpcId: aws.String("vpc-12345678"),
+							},
+							{
+								AvailabilityZone: aws.String("ap-northeast-1a"),
+								SubnetId:         aws.String("subnet-yyyyyyyy"),
+								Tags: []types.Tag{
+									{
+										Key:   aws.String("Name"),
+										Value: aws.String("private-a"),
+									},
+								},
+								VpcId: aws.String("vpc-12345678"),
+							},
+							{
+								AvailabilityZone: aws.String("ap-northeast-1c"),
+								SubnetId:         aws.String("subnet-wwwwwwww"),
+								VpcId:            aws.String("vpc-12345678"),
+							},
+						},
+					},
+				},
+				routeTables: testSubnetRouteTables,
+			},
+			want: `+--------------+------------------+-----------------+-----------------+
+|     VPC      |       TIER       | AP-NORTHEAST-1A | AP-NORTHEAST-1C |
++--------------+------------------+-----------------+-----------------+
+| vpc-12345678 | public           | public-a        |                 |
+| vpc-12345678 | private-with-NAT | private-a       | subnet-wwwwwwww |
++--------------+------------------+-----------------+-----------------+
 `,
 		},
 	}
 	for _, tt := range tests {
 		var buf bytes.Buffer
 		t.Run(tt.name, func(t *testing.T) {
-			showSubnets(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			showSubnetAzBalance(tt.args.outputs, tt.args.routeTables, tablewriter.NewWriter(&buf))
 			if buf.String() != tt.want {
 				t.Errorf("failed to test: %s\n", tt.name)
 				t.Errorf("\nwant:\n%s\n", tt.want)
@@ -101,3 +243,80 @@ func Test_showSubnets(t *testing.T) {
 		})
 	}
 }
+
+func Test_routeTableTier(t *testing.T) {
+	tests := []struct {
+		name  string
+		route types.Route
+		want  string
+	}{
+		{
+			name: "internet gateway",
+			route: types.Route{
+				DestinationCidrBlock: aws.String("0.0.0.0/0"),
+				GatewayId:            aws.String("igw-12345678"),
+			},
+			want: "public",
+		},
+		{
+			name: "NAT gateway",
+			route: types.Route{
+				DestinationCidrBlock: aws.String("0.0.0.0/0"),
+				NatGatewayId:         aws.String("nat-12345678"),
+			},
+			want: "private-with-NAT",
+		},
+		{
+			name: "NAT instance",
+			route: types.Route{
+				DestinationCidrBlock: aws.String("0.0.0.0/0"),
+				InstanceId:           aws.String("i-12345678"),
+				NetworkInterfaceId:   aws.String("eni-12345678"),
+			},
+			want: "private-with-NAT",
+		},
+		{
+			name: "transit gateway",
+			route: types.Route{
+				DestinationCidrBlock: aws.String("0.0.0.0/0"),
+				TransitGatewayId:     aws.String("tgw-12345678"),
+			},
+			want: "private-other",
+		},
+		{
+			name: "network interface",
+			route: types.Route{
+				DestinationCidrBlock: aws.String("0.0.0.0/0"),
+				NetworkInterfaceId:   aws.String("eni-12345678"),
+			},
+			want: "private-other",
+		},
+		{
+			name: "blackhole",
+			route: types.Route{
+				DestinationCidrBlock: aws.String("0.0.0.0/0"),
+				NatGatewayId:         aws.String("nat-12345678"),
+				State:                types.RouteStateBlackhole,
+			},
+			want: "isolated",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := types.RouteTable{
+				Routes: []types.Route{
+					{
+						DestinationCidrBlock: aws.String("10.0.0.0/16"),
+						GatewayId:            aws.String("local"),
+					},
+					tt.route,
+				},
+			}
+			if got := routeTableTier(rt); got != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", got)
+			}
+		})
+	}
+}
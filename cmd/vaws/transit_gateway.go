@@ -0,0 +1,330 @@
+package vaws
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"os"
+	"sort"
+)
+
+const (
+	transitGatewayMaxResult      = 1000
+	transitGatewayRouteMaxResult = 1000
+)
+
+// transitGatewayOutputs holds the responses of the APIs that the tgw command combines.
+// routeTables, propagations and staticRoutes are only fetched for the route table view.
+type transitGatewayOutputs struct {
+	transitGateways []*ec2.DescribeTransitGatewaysOutput
+	attachments     []*ec2.DescribeTransitGatewayAttachmentsOutput
+	routeTables     []*ec2.DescribeTransitGatewayRouteTablesOutput
+	// route table ID -> propagations
+	propagations map[string][]*ec2.GetTransitGatewayRouteTablePropagationsOutput
+	// route table ID -> static routes
+	staticRoutes map[string]*ec2.SearchTransitGatewayRoutesOutput
+	vpcs         []*ec2.DescribeVpcsOutput
+}
+
+// transitGatewayCmd represents the tgw command
+var transitGatewayCmd = &cobra.Command{
+	Use:   "tgw",
+	Short: "Show Transit Gateways and attachments",
+	Long:  `Show Transit Gateways and attachments`,
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cmd.Flags().GetString("aws-profile")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if profile != "" {
+			err := os.Setenv("AWS_PROFILE", profile)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		}
+		sortPosition, err := cmd.Flags().GetInt("sort-position")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		routeTableFlg, err := cmd.Flags().GetBool("route-table")
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		outputs, err := getTransitGateways(newAwsConfig(), routeTableFlg)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if routeTableFlg {
+			err = showTransitGatewayRouteTables(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		} else {
+			err = showTransitGatewayAttachments(outputs, tablewriter.NewWriter(os.Stdout), sortPosition)
+		}
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(transitGatewayCmd)
+	transitGatewayCmd.Flags().BoolP("route-table", "r", false, "Show route tables with propagations and static routes")
+}
+
+func getTransitGateways(cfg aws.Config, withRouteTables bool) (*transitGatewayOutputs, error) {
+	var err error
+	client := ec2.NewFromConfig(cfg)
+	outputs := &transitGatewayOutputs{
+		propagations: map[string][]*ec2.GetTransitGatewayRouteTablePropagationsOutput{},
+		staticRoutes: map[string]*ec2.SearchTransitGatewayRoutesOutput{},
+	}
+	// The DescribeTransitGateways API executes the API once at the beginning because the NextToken "" is disallowed
+	tgw, err := client.DescribeTransitGateways(context.TODO(), &ec2.DescribeTransitGatewaysInput{
+		MaxResults: aws.Int32(transitGatewayMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs.transitGateways = append(outputs.transitGateways, tgw)
+	for tgw.NextToken != nil {
+		tgw, err = client.DescribeTransitGateways(context.TODO(), &ec2.DescribeTransitGatewaysInput{
+			MaxResults: aws.Int32(transitGatewayMaxResult),
+			NextToken:  tgw.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs.transitGateways = append(outputs.transitGateways, tgw)
+	}
+	attachment, err := client.DescribeTransitGatewayAttachments(context.TODO(), &ec2.DescribeTransitGatewayAttachmentsInput{
+		MaxResults: aws.Int32(transitGatewayMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs.attachments = append(outputs.attachments, attachment)
+	for attachment.NextToken != nil {
+		attachment, err = client.DescribeTransitGatewayAttachments(context.TODO(), &ec2.DescribeTransitGatewayAttachmentsInput{
+			MaxResults: aws.Int32(transitGatewayMaxResult),
+			NextToken:  attachment.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs.attachments = append(outputs.attachments, attachment)
+	}
+	outputs.vpcs, err = getVpc(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !withRouteTables {
+		return outputs, nil
+	}
+	routeTable, err := client.DescribeTransitGatewayRouteTables(context.TODO(), &ec2.DescribeTransitGatewayRouteTablesInput{
+		MaxResults: aws.Int32(transitGatewayMaxResult),
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputs.routeTables = append(outputs.routeTables, routeTable)
+	for routeTable.NextToken != nil {
+		routeTable, err = client.DescribeTransitGatewayRouteTables(context.TODO(), &ec2.DescribeTransitGatewayRouteTablesInput{
+			MaxResults: aws.Int32(transitGatewayMaxResult),
+			NextToken:  routeTable.NextToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		outputs.routeTables = append(outputs.routeTables, routeTable)
+	}
+	for _, o := range outputs.routeTables {
+		for _, rt := range o.TransitGatewayRouteTables {
+			id := rt.TransitGatewayRouteTableId
+			propagation := &ec2.GetTransitGatewayRouteTablePropagationsOutput{
+				NextToken: aws.String(""),
+			}
+			for propagation.NextToken != nil {
+				input := &ec2.GetTransitGatewayRouteTablePropagationsInput{
+					MaxResults:                 aws.Int32(transitGatewayMaxResult),
+					TransitGatewayRouteTableId: id,
+				}
+				if *propagation.NextToken != "" {
+					input.NextToken = propagation.NextToken
+				}
+				propagation, err = client.GetTransitGatewayRouteTablePropagations(context.TODO(), input)
+				if err != nil {
+					return nil, err
+				}
+				outputs.propagations[*id] = append(outputs.propagations[*id], propagation)
+			}
+			// The SearchTransitGatewayRoutes API has no pagination and returns up to 1000 routes.
+			// AdditionalRoutesAvailable tells that more routes are left out, which the table shows.
+			staticRoute, err := client.SearchTransitGatewayRoutes(context.TODO(), &ec2.SearchTransitGatewayRoutesInput{
+				Filters: []types.Filter{
+					{
+						Name:   aws.String("type"),
+						Values: []string{"static"},
+					},
+				},
+				MaxResults:                 aws.Int32(transitGatewayRouteMaxResult),
+				TransitGatewayRouteTableId: id,
+			})
+			if err != nil {
+				return nil, err
+			}
+			outputs.staticRoutes[*id] = staticRoute
+		}
+	}
+	return outputs, nil
+}
+
+func showTransitGatewayAttachments(outputs *transitGatewayOutputs, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"TRANSIT GATEWAY", "OWNER", "STATE", "ATTACHMENT", "NAME", "RESOURCE TYPE", "RESOURCE", "RESOURCE OWNER", "ROUTE TABLE", "ATTACHMENT STATE"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	vpcNames := getVpcNames(outputs.vpcs)
+	attachments := map[string][]types.TransitGatewayAttachment{}
+	for _, o := range outputs.attachments {
+		for _, attachment := range o.TransitGatewayAttachments {
+			attachments[*attachment.TransitGatewayId] = append(attachments[*attachment.TransitGatewayId], attachment)
+		}
+	}
+	var records [][]string
+	for _, o := range outputs.transitGateways {
+		for _, tgw := range o.TransitGateways {
+			transitGateway := formatNameId(getNameTag(tgw.Tags), *tgw.TransitGatewayId)
+			owner := ""
+			if tgw.OwnerId != nil {
+				owner = *tgw.OwnerId
+			}
+			if len(attachments[*tgw.TransitGatewayId]) == 0 {
+				records = append(records, []string{transitGateway, owner, string(tgw.State), "", "", "", "", "", "", ""})
+				continue
+			}
+			for _, attachment := range attachments[*tgw.TransitGatewayId] {
+				resourceOwner := ""
+				if attachment.ResourceOwnerId != nil {
+					resourceOwner = *attachment.ResourceOwnerId
+				}
+				routeTable := ""
+				if attachment.Association != nil && attachment.Association.TransitGatewayRouteTableId != nil {
+					routeTable = *attachment.Association.TransitGatewayRouteTableId
+				}
+				records = append(records, []string{
+					transitGateway,
+					owner,
+					string(tgw.State),
+					*attachment.TransitGatewayAttachmentId,
+					getNameTag(attachment.Tags),
+					string(attachment.ResourceType),
+					transitGatewayResource(attachment.ResourceType, attachment.ResourceId, vpcNames),
+					resourceOwner,
+					routeTable,
+					string(attachment.State),
+				})
+			}
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+func showTransitGatewayRouteTables(outputs *transitGatewayOutputs, table *tablewriter.Table, sortPosition int) error {
+	header := []string{"ROUTE TABLE", "TRANSIT GATEWAY", "TYPE", "DESTINATION", "ATTACHMENT", "RESOURCE TYPE", "RESOURCE", "STATE"}
+	if sortPosition > len(header) || 1 > sortPosition {
+		return fmt.Errorf("out of sort range number when using --sort option")
+	}
+	recordIndex := sortPosition - 1
+	table.SetHeader(header)
+	vpcNames := getVpcNames(outputs.vpcs)
+	var records [][]string
+	for _, o := range outputs.routeTables {
+		for _, rt := range o.TransitGatewayRouteTables {
+			id := *rt.TransitGatewayRouteTableId
+			routeTable := formatNameId(getNameTag(rt.Tags), id)
+			for _, p := range outputs.propagations[id] {
+				for _, propagation := range p.TransitGatewayRouteTablePropagations {
+					records = append(records, []string{
+						routeTable,
+						*rt.TransitGatewayId,
+						"propagation",
+						"",
+						*propagation.TransitGatewayAttachmentId,
+						string(propagation.ResourceType),
+						transitGatewayResource(propagation.ResourceType, propagation.ResourceId, vpcNames),
+						string(propagation.State),
+					})
+				}
+			}
+			if outputs.staticRoutes[id] == nil {
+				continue
+			}
+			for _, route := range outputs.staticRoutes[id].Routes {
+				destination := ""
+				if route.DestinationCidrBlock != nil {
+					destination = *route.DestinationCidrBlock
+				} else if route.PrefixListId != nil {
+					destination = *route.PrefixListId
+				}
+				var attachmentIds, resourceTypes, resources []string
+				for _, attachment := range route.TransitGatewayAttachments {
+					attachmentIds = append(attachmentIds, *attachment.TransitGatewayAttachmentId)
+					resourceTypes = append(resourceTypes, string(attachment.ResourceType))
+					resources = append(resources, transitGatewayResource(attachment.ResourceType, attachment.ResourceId, vpcNames))
+				}
+				records = append(records, []string{
+					routeTable,
+					*rt.TransitGatewayId,
+					string(route.Type),
+					destination,
+					joinValues(attachmentIds),
+					joinValues(resourceTypes),
+					joinValues(resources),
+					string(route.State),
+				})
+			}
+			if aws.ToBool(outputs.staticRoutes[id].AdditionalRoutesAvailable) {
+				records = append(records, []string{
+					routeTable,
+					*rt.TransitGatewayId,
+					"static",
+					fmt.Sprintf("routes after the first %d", transitGatewayRouteMaxResult),
+					"",
+					"",
+					"",
+					"truncated",
+				})
+			}
+		}
+	}
+	// Keep propagations before static routes within the same route table
+	sort.SliceStable(records, func(i, j int) bool { return records[i][recordIndex] < records[j][recordIndex] })
+	table.AppendBulk(records)
+	table.Render()
+	return nil
+}
+
+// transitGatewayResource returns the attached resource, with the VPC name when the VPC is in the current account and region.
+func transitGatewayResource(resourceType types.TransitGatewayAttachmentResourceType, resourceId *string, vpcNames map[string]string) string {
+	if resourceId == nil {
+		return ""
+	}
+	if resourceType == types.TransitGatewayAttachmentResourceTypeVpc {
+		return formatNameId(vpcNames[*resourceId], *resourceId)
+	}
+	return *resourceId
+}
@@ -0,0 +1,236 @@
+package vaws
+
+import (
+	"bytes"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/olekukonko/tablewriter"
+	"testing"
+)
+
+var testTransitGatewayOutputs = &transitGatewayOutputs{
+	transitGateways: []*ec2.DescribeTransitGatewaysOutput{
+		{
+			TransitGateways: []types.TransitGateway{
+				{
+					OwnerId: aws.String("111111111111"),
+					State:   "available",
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("core"),
+						},
+					},
+					TransitGatewayId: aws.String("tgw-11111111"),
+				},
+				{
+					OwnerId:          aws.String("111111111111"),
+					State:            "pending",
+					TransitGatewayId: aws.String("tgw-22222222"),
+				},
+			},
+		},
+	},
+	attachments: []*ec2.DescribeTransitGatewayAttachmentsOutput{
+		{
+			TransitGatewayAttachments: []types.TransitGatewayAttachment{
+				{
+					Association: &types.TransitGatewayAttachmentAssociation{
+						State:                      "associated",
+						TransitGatewayRouteTableId: aws.String("tgw-rtb-11111111"),
+					},
+					ResourceId:                 aws.String("vpc-11111111"),
+					ResourceOwnerId:            aws.String("111111111111"),
+					ResourceType:               "vpc",
+					State:                      "available",
+					TransitGatewayAttachmentId: aws.String("tgw-attach-11111111"),
+					TransitGatewayId:           aws.String("tgw-11111111"),
+				},
+				{
+					ResourceId:                 aws.String("vpn-11111111"),
+					ResourceOwnerId:            aws.String("111111111111"),
+					ResourceType:               "vpn",
+					State:                      "available",
+					TransitGatewayAttachmentId: aws.String("tgw-attach-22222222"),
+					TransitGatewayId:           aws.String("tgw-11111111"),
+				},
+			},
+		},
+	},
+	routeTables: []*ec2.DescribeTransitGatewayRouteTablesOutput{
+		{
+			TransitGatewayRouteTables: []types.TransitGatewayRouteTable{
+				{
+					State: "available",
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("default"),
+						},
+					},
+					TransitGatewayId:           aws.String("tgw-11111111"),
+					TransitGatewayRouteTableId: aws.String("tgw-rtb-11111111"),
+				},
+			},
+		},
+	},
+	propagations: map[string][]*ec2.GetTransitGatewayRouteTablePropagationsOutput{
+		"tgw-rtb-11111111": {
+			{
+				TransitGatewayRouteTablePropagations: []types.TransitGatewayRouteTablePropagation{
+					{
+						ResourceId:                 aws.String("vpc-11111111"),
+						ResourceType:               "vpc",
+						State:                      "enabled",
+						TransitGatewayAttachmentId: aws.String("tgw-attach-11111111"),
+					},
+				},
+			},
+		},
+	},
+	staticRoutes: map[string]*ec2.SearchTransitGatewayRoutesOutput{
+		"tgw-rtb-11111111": {
+			Routes: []types.TransitGatewayRoute{
+				{
+					DestinationCidrBlock: aws.String("192.168.0.0/16"),
+					State:                "active",
+					TransitGatewayAttachments: []types.TransitGatewayRouteAttachment{
+						{
+							ResourceId:                 aws.String("vpn-11111111"),
+							ResourceType:               "vpn",
+							TransitGatewayAttachmentId: aws.String("tgw-attach-22222222"),
+						},
+					},
+					Type: "static",
+				},
+				{
+					DestinationCidrBlock: aws.String("10.99.0.0/16"),
+					State:                "blackhole",
+					Type:                 "static",
+				},
+			},
+		},
+	},
+	vpcs: []*ec2.DescribeVpcsOutput{
+		{
+			Vpcs: []types.Vpc{
+				{
+					Tags: []types.Tag{
+						{
+							Key:   aws.String("Name"),
+							Value: aws.String("app"),
+						},
+					},
+					VpcId: aws.String("vpc-11111111"),
+				},
+			},
+		},
+	},
+}
+
+func Test_showTransitGatewayAttachments(t *testing.T) {
+	type args struct {
+		outputs      *transitGatewayOutputs
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs:      testTransitGatewayOutputs,
+				sortPosition: 4,
+			},
+			want: `+--------------------+--------------+-----------+---------------------+------+---------------+-------------------+----------------+------------------+------------------+
+|  TRANSIT GATEWAY   |    OWNER     |   STATE   |     ATTACHMENT      | NAME | RESOURCE TYPE |     RESOURCE      | RESOURCE OWNER |   ROUTE TABLE    | ATTACHMENT STATE |
++--------------------+--------------+-----------+---------------------+------+---------------+-------------------+----------------+------------------+------------------+
+| tgw-22222222       | 111111111111 | pending   |                     |      |               |                   |                |                  |                  |
+| core(tgw-11111111) | 111111111111 | available | tgw-attach-11111111 |      | vpc           | app(vpc-11111111) |   111111111111 | tgw-rtb-11111111 | available        |
+| core(tgw-11111111) | 111111111111 | available | tgw-attach-22222222 |      | vpn           | vpn-11111111      |   111111111111 |                  | available        |
++--------------------+--------------+-----------+---------------------+------+---------------+-------------------+----------------+------------------+------------------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showTransitGatewayAttachments(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
+
+func Test_showTransitGatewayRouteTables(t *testing.T) {
+	type args struct {
+		outputs      *transitGatewayOutputs
+		sortPosition int
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "default",
+			args: args{
+				outputs:      testTransitGatewayOutputs,
+				sortPosition: 1,
+			},
+			want: `+---------------------------+-----------------+-------------+----------------+---------------------+---------------+-------------------+-----------+
+|        ROUTE TABLE        | TRANSIT GATEWAY |    TYPE     |  DESTINATION   |     ATTACHMENT      | RESOURCE TYPE |     RESOURCE      |   STATE   |
++---------------------------+-----------------+-------------+----------------+---------------------+---------------+-------------------+-----------+
+| default(tgw-rtb-11111111) | tgw-11111111    | propagation |                | tgw-attach-11111111 | vpc           | app(vpc-11111111) | enabled   |
+| default(tgw-rtb-11111111) | tgw-11111111    | static      | 192.168.0.0/16 | tgw-attach-22222222 | vpn           | vpn-11111111      | active    |
+| default(tgw-rtb-11111111) | tgw-11111111    | static      | 10.99.0.0/16   |                     |               |                   | blackhole |
++---------------------------+-----------------+-------------+----------------+---------------------+---------------+-------------------+-----------+
+`,
+		},
+		{
+			name: "truncated",
+			args: args{
+				outputs: &transitGatewayOutputs{
+					routeTables: testTransitGatewayOutputs.routeTables,
+					staticRoutes: map[string]*ec2.SearchTransitGatewayRoutesOutput{
+						"tgw-rtb-11111111": {
+							AdditionalRoutesAvailable: aws.Bool(true),
+							Routes: []types.TransitGatewayRoute{
+								{
+									DestinationCidrBlock: aws.String("10.99.0.0/16"),
+									State:                "blackhole",
+									Type:                 "static",
+								},
+							},
+						},
+					},
+				},
+				sortPosition: 1,
+			},
+			want: `+---------------------------+-----------------+--------+-----------------------------+------------+---------------+----------+-----------+
+|        ROUTE TABLE        | TRANSIT GATEWAY |  TYPE  |         DESTINATION         | ATTACHMENT | RESOURCE TYPE | RESOURCE |   STATE   |
++---------------------------+-----------------+--------+-----------------------------+------------+---------------+----------+-----------+
+| default(tgw-rtb-11111111) | tgw-11111111    | static | 10.99.0.0/16                |            |               |          | blackhole |
+| default(tgw-rtb-11111111) | tgw-11111111    | static | routes after the first 1000 |            |               |          | truncated |
++---------------------------+-----------------+--------+-----------------------------+------------+---------------+----------+-----------+
+`,
+		},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		t.Run(tt.name, func(t *testing.T) {
+			showTransitGatewayRouteTables(tt.args.outputs, tablewriter.NewWriter(&buf), tt.args.sortPosition)
+			if buf.String() != tt.want {
+				t.Errorf("failed to test: %s\n", tt.name)
+				t.Errorf("\nwant:\n%s\n", tt.want)
+				t.Errorf("\ninput:\n%s\n", buf.String())
+			}
+		})
+	}
+}
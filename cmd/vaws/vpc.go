@@ -100,3 +100,14 @@ func showVpc(outputs []*ec2.DescribeVpcsOutput, table *tablewriter.Table, sortPo
 	table.Render()
 	return nil
 }
+
+// getVpcNames returns the Name tag of each VPC ID.
+func getVpcNames(outputs []*ec2.DescribeVpcsOutput) map[string]string {
+	names := map[string]string{}
+	for _, o := range outputs {
+		for _, v := range o.Vpcs {
+			names[*v.VpcId] = getNameTag(v.Tags)
+		}
+	}
+	return names
+}